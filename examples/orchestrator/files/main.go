@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/jantytgat/go-jobs/pkg/job"
+	"github.com/jantytgat/go-jobs/pkg/orchestrator"
+	_ "github.com/jantytgat/go-jobs/pkg/taskLibrary" // registers the task kinds of the library
+)
+
+func main() {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	o, err := orchestrator.New(logger, "files", 4)
+	if err != nil {
+		panic(err)
+	}
+
+	// load the job files in the jobs directory, and apply changes to the files while the orchestrator runs
+	loader := job.NewLoader("jobs", o.Catalog, job.WithLoaderInterval(2*time.Second))
+	go loader.Watch(ctx, func(r job.LoadResult) {
+		fmt.Printf("added %d, updated %d, deleted %d jobs\n", len(r.Added), len(r.Updated), len(r.Deleted))
+		for _, err := range r.Errors {
+			fmt.Println(err)
+		}
+	})
+
+	_ = o.Start(ctx)
+	<-ctx.Done()
+	o.Stop()
+}
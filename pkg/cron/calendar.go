@@ -0,0 +1,220 @@
+package cron
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// calendarSearchDays limits how many days are searched for an included day when moving an occurrence.
+	calendarSearchDays = 366
+
+	dateListLayout = "2006-01-02"
+	icsDateLayout  = "20060102"
+)
+
+var calendars = struct {
+	sync.RWMutex
+	m map[string]Calendar
+}{m: make(map[string]Calendar)}
+
+// NewCalendar returns a Calendar with the input name, which excludes the days set by the options.
+func NewCalendar(name string, opts ...CalendarOption) Calendar {
+	c := Calendar{
+		name:  name,
+		dates: make(map[date]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// LoadCalendar returns a Calendar which excludes the dates in the file at path.
+// Files with the .ics extension are read as iCalendar files, all other files as date lists.
+// Returns an error if the file cannot be read or parsed.
+func LoadCalendar(name string, path string, opts ...CalendarOption) (Calendar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Calendar{}, fmt.Errorf("cannot open calendar %s: %w", name, err)
+	}
+	defer f.Close()
+
+	var dates []time.Time
+	if strings.EqualFold(filepath.Ext(path), ".ics") {
+		dates, err = ParseICS(f)
+	} else {
+		dates, err = ParseDateList(f)
+	}
+	if err != nil {
+		return Calendar{}, fmt.Errorf("cannot load calendar %s from %s: %w", name, path, err)
+	}
+	return NewCalendar(name, append([]CalendarOption{WithExcludedDates(dates...)}, opts...)...), nil
+}
+
+// LookupCalendar returns the calendar registered with the input name.
+func LookupCalendar(name string) (Calendar, bool) {
+	calendars.RLock()
+	defer calendars.RUnlock()
+
+	c, found := calendars.m[name]
+	return c, found
+}
+
+// RegisterCalendar makes calendar c available to schedule expressions using the CAL=<name> prefix.
+// A calendar which was registered before with the same name is replaced.
+func RegisterCalendar(c Calendar) error {
+	if c.name == "" {
+		return fmt.Errorf("cannot register calendar without name")
+	}
+
+	calendars.Lock()
+	defer calendars.Unlock()
+	calendars.m[c.name] = c
+	return nil
+}
+
+// ParseDateList returns the dates in r, which contains a single date in YYYY-MM-DD format per line.
+// The date can be followed by a description, empty lines and lines starting with # are ignored.
+func ParseDateList(r io.Reader) ([]time.Time, error) {
+	var (
+		dates   []time.Time
+		scanner = bufio.NewScanner(r)
+	)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		d, err := time.Parse(dateListLayout, strings.Fields(text)[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid date on line %d: %w", line, err)
+		}
+		dates = append(dates, d)
+	}
+	return dates, scanner.Err()
+}
+
+// ParseICS returns the dates covered by the events in iCalendar data r.
+// An event covers every day from its start date up to, but not including, its end date.
+// Recurrence rules are not expanded, only the dates of the events themselves are returned.
+func ParseICS(r io.Reader) ([]time.Time, error) {
+	var (
+		dates      []time.Time
+		lines      []string
+		scanner    = bufio.NewScanner(r)
+		inEvent    bool
+		start, end time.Time
+	)
+
+	// Unfold long lines, which continue on the next line starting with a space or a tab
+	for scanner.Scan() {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+			lines[len(lines)-1] += text[1:]
+			continue
+		}
+		lines = append(lines, text)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, text := range lines {
+		property, value, _ := strings.Cut(text, ":")
+		name, _, _ := strings.Cut(property, ";")
+
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end = true, time.Time{}, time.Time{}
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+
+			d, err := parseICSDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %s: %w", name, value, err)
+			}
+			if strings.EqualFold(name, "DTSTART") {
+				start = d
+			} else {
+				end = d
+			}
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+
+			if start.IsZero() {
+				return nil, fmt.Errorf("event without DTSTART")
+			}
+			dates = append(dates, start)
+			for d := start.AddDate(0, 0, 1); d.Before(end); d = d.AddDate(0, 0, 1) {
+				dates = append(dates, d)
+			}
+			inEvent = false
+		}
+	}
+	return dates, nil
+}
+
+// Calendar defines a set of days which are excluded from a schedule, such as public holidays or weekends.
+// Calendar should always be created using NewCalendar or LoadCalendar for proper initialization.
+type Calendar struct {
+	name     string
+	dates    map[date]struct{}
+	weekdays [7]bool
+}
+
+// Excludes checks if the date of t is excluded from the calendar.
+func (c Calendar) Excludes(t time.Time) bool {
+	if c.weekdays[t.Weekday()] {
+		return true
+	}
+	_, found := c.dates[dateOf(t)]
+	return found
+}
+
+// Name returns the name of the calendar.
+func (c Calendar) Name() string {
+	return c.name
+}
+
+// equal checks if calendar o has the same name and excludes the same days.
+func (c Calendar) equal(o Calendar) bool {
+	return c.name == o.name && c.weekdays == o.weekdays && maps.Equal(c.dates, o.dates)
+}
+
+// date identifies a day in a calendar, regardless of location.
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// dateOf returns the date of t in its own location.
+func dateOf(t time.Time) date {
+	year, month, day := t.Date()
+	return date{year: year, month: month, day: day}
+}
+
+// parseICSDate returns the date of an iCalendar DATE or DATE-TIME value, such as 20250101 or 20250101T090000Z.
+func parseICSDate(value string) (time.Time, error) {
+	if len(value) < len(icsDateLayout) {
+		return time.Time{}, fmt.Errorf("expected a date in %s format", icsDateLayout)
+	}
+	return time.Parse(icsDateLayout, value[:len(icsDateLayout)])
+}
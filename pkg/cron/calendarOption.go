@@ -0,0 +1,25 @@
+package cron
+
+import "time"
+
+type CalendarOption func(*Calendar)
+
+// WithExcludedDates excludes the dates of the input times from the calendar.
+// Only the year, month and day of the times are used, in their own location.
+func WithExcludedDates(dates ...time.Time) CalendarOption {
+	return func(c *Calendar) {
+		for _, d := range dates {
+			c.dates[dateOf(d)] = struct{}{}
+		}
+	}
+}
+
+// WithExcludedWeekdays excludes every occurrence of the input weekdays from the calendar.
+// Use WithExcludedWeekdays(time.Saturday, time.Sunday) for a business day calendar.
+func WithExcludedWeekdays(weekdays ...time.Weekday) CalendarOption {
+	return func(c *Calendar) {
+		for _, w := range weekdays {
+			c.weekdays[w] = true
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package cron
+
+const (
+	CalendarSkip     CalendarPolicy = iota // occurrences on excluded days are skipped
+	CalendarNext                           // occurrences on excluded days are moved to the next included day
+	CalendarPrevious                       // occurrences on excluded days are moved to the previous included day
+)
+
+var calendarPolicyStrings = []string{"skip", "next", "previous"}
+
+type CalendarPolicy int
+
+func (p CalendarPolicy) String() string {
+	return calendarPolicyStrings[p]
+}
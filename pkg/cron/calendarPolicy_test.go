@@ -0,0 +1,20 @@
+package cron
+
+import "testing"
+
+func TestCalendarPolicy_String(t *testing.T) {
+	var (
+		result []string
+		wanted = calendarPolicyStrings
+	)
+
+	for i := 0; i < len(wanted); i++ {
+		result = append(result, CalendarPolicy(i).String())
+	}
+
+	for j := 0; j < len(wanted); j++ {
+		if result[j] != wanted[j] {
+			t.Errorf("invalid string: got %s expected %s", result[j], wanted[j])
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package cron
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendar_Excludes(t *testing.T) {
+	c := NewCalendar("test",
+		WithExcludedDates(time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)),
+		WithExcludedWeekdays(time.Saturday, time.Sunday))
+
+	var tests = []struct {
+		time   time.Time
+		wanted bool
+	}{
+		{time.Date(2025, 12, 24, 23, 59, 59, 0, time.UTC), false},
+		{time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 12, 25, 18, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 12, 26, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2025, 12, 27, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 12, 28, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.time.String(), func(t *testing.T) {
+			if got := c.Excludes(tt.time); got != tt.wanted {
+				t.Errorf("Excludes(%s) = %t, wanted %t", tt.time, got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestParseDateList(t *testing.T) {
+	var tests = []struct {
+		input   string
+		wanted  []string
+		success bool
+	}{
+		{"2025-01-01\n2025-12-25 Christmas\n", []string{"2025-01-01", "2025-12-25"}, true},
+		{"# holidays\n\n  2025-05-01  \n", []string{"2025-05-01"}, true},
+		{"", nil, true},
+
+		{"2025-13-01", nil, false},
+		{"01/01/2025", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			dates, err := ParseDateList(strings.NewReader(tt.input))
+			if (err == nil) != tt.success {
+				t.Fatalf("unexpected result with error: %v", err)
+			}
+			assertDates(t, dates, tt.wanted)
+		})
+	}
+}
+
+func TestParseICS(t *testing.T) {
+	var tests = []struct {
+		name    string
+		input   string
+		wanted  []string
+		success bool
+	}{
+		{
+			"all day events",
+			"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20250101\r\nDTEND;VALUE=DATE:20250102\r\nSUMMARY:New Year\r\nEND:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20251225\r\nDTEND;VALUE=DATE:20251227\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			[]string{"2025-01-01", "2025-12-25", "2025-12-26"},
+			true,
+		},
+		{
+			"date time without end",
+			"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;TZID=Europe/Brussels:20250721T000000\nEND:VEVENT\nEND:VCALENDAR\n",
+			[]string{"2025-07-21"},
+			true,
+		},
+		{
+			"folded lines",
+			"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DA\n TE:20250501\nEND:VEVENT\nEND:VCALENDAR\n",
+			[]string{"2025-05-01"},
+			true,
+		},
+		{"missing start", "BEGIN:VEVENT\nSUMMARY:Holiday\nEND:VEVENT\n", nil, false},
+		{"invalid start", "BEGIN:VEVENT\nDTSTART:2025\nEND:VEVENT\n", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates, err := ParseICS(strings.NewReader(tt.input))
+			if (err == nil) != tt.success {
+				t.Fatalf("unexpected result with error: %v", err)
+			}
+			assertDates(t, dates, tt.wanted)
+		})
+	}
+}
+
+func TestLoadCalendar(t *testing.T) {
+	dir := t.TempDir()
+	list := filepath.Join(dir, "holidays.txt")
+	ics := filepath.Join(dir, "holidays.ics")
+	_ = os.WriteFile(list, []byte("2025-12-25\n"), 0o600)
+	_ = os.WriteFile(ics, []byte("BEGIN:VEVENT\nDTSTART;VALUE=DATE:20251225\nEND:VEVENT\n"), 0o600)
+
+	for _, path := range []string{list, ics} {
+		c, err := LoadCalendar("holidays", path)
+		if err != nil {
+			t.Fatalf("cannot load %s: %v", path, err)
+		}
+		if !c.Excludes(time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("calendar loaded from %s does not exclude 2025-12-25", path)
+		}
+	}
+
+	if _, err := LoadCalendar("missing", filepath.Join(dir, "missing.ics")); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+func TestRegisterCalendar(t *testing.T) {
+	if err := RegisterCalendar(NewCalendar("")); err == nil {
+		t.Errorf("expected an error for a calendar without name")
+	}
+
+	if err := RegisterCalendar(NewCalendar("registered")); err != nil {
+		t.Fatalf("cannot register calendar: %v", err)
+	}
+	if c, found := LookupCalendar("registered"); !found || c.Name() != "registered" {
+		t.Errorf("LookupCalendar() = %s, %t", c.Name(), found)
+	}
+	if _, found := LookupCalendar("unregistered"); found {
+		t.Errorf("found unregistered calendar")
+	}
+}
+
+func assertDates(t *testing.T, dates []time.Time, wanted []string) {
+	t.Helper()
+
+	if len(dates) != len(wanted) {
+		t.Fatalf("got %d dates %v, wanted %v", len(dates), dates, wanted)
+	}
+	for i, d := range dates {
+		if got := d.Format(dateListLayout); got != wanted[i] {
+			t.Errorf("date %d = %s, wanted %s", i, got, wanted[i])
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package cron
+
+import (
+	"encoding"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	compositeSeparator = ";"
+
+	// compositeMaxSteps limits how many candidate times Next and Prev of an intersect or except composite will evaluate.
+	compositeMaxSteps = 10000
+)
+
+// spanner is implemented by timetables which are due during whole spans of time, such as a schedule with a wildcard second.
+type spanner interface {
+	// span returns the start and end of the span of time around t during which the timetable is due.
+	span(t time.Time) (time.Time, time.Time)
+}
+
+// Union returns a Composite which is due when any of the timetables is due.
+func Union(timetables ...Timetable) Composite {
+	return Composite{
+		operator:   CompositeUnion,
+		timetables: timetables,
+	}
+}
+
+// Intersect returns a Composite which is due when all timetables are due.
+func Intersect(timetables ...Timetable) Composite {
+	return Composite{
+		operator:   CompositeIntersect,
+		timetables: timetables,
+	}
+}
+
+// Except returns a Composite which is due when base is due, unless any of the blackout timetables is due at the same time.
+func Except(base Timetable, blackouts ...Timetable) Composite {
+	return Composite{
+		operator:   CompositeExcept,
+		timetables: append([]Timetable{base}, blackouts...),
+	}
+}
+
+// Composite defines a timetable which combines other timetables using an operator.
+// Composite should always be created using Union, Intersect or Except for proper initialization.
+type Composite struct {
+	operator   CompositeOperator
+	timetables []Timetable
+}
+
+// Operator returns the operator used to combine the timetables.
+func (c Composite) Operator() CompositeOperator {
+	return c.operator
+}
+
+// Timetables returns the combined timetables.
+// For CompositeExcept, the first timetable is the base and the others are the blackouts.
+func (c Composite) Timetables() []Timetable {
+	return c.timetables
+}
+
+// IsDue checks if the composite timetable is due at t.
+func (c Composite) IsDue(t time.Time) bool {
+	if len(c.timetables) == 0 {
+		return false
+	}
+
+	switch c.operator {
+	case CompositeUnion:
+		for _, tt := range c.timetables {
+			if tt.IsDue(t) {
+				return true
+			}
+		}
+		return false
+	case CompositeIntersect:
+		for _, tt := range c.timetables {
+			if !tt.IsDue(t) {
+				return false
+			}
+		}
+		return true
+	default:
+		return c.timetables[0].IsDue(t) && c.blackout(t) == nil
+	}
+}
+
+// Next returns the first time after the input time at which the composite timetable is due.
+// The search is limited to searchYears after the input time and to compositeMaxSteps candidate times.
+func (c Composite) Next(after time.Time) (time.Time, bool) {
+	if len(c.timetables) == 0 {
+		return time.Time{}, false
+	}
+
+	limit := after.AddDate(searchYears, 0, 0)
+	switch c.operator {
+	case CompositeUnion:
+		var (
+			next  time.Time
+			found bool
+		)
+		for _, tt := range c.timetables {
+			if t, ok := tt.Next(after); ok && (!found || t.Before(next)) {
+				next, found = t, true
+			}
+		}
+		return next, found
+	case CompositeIntersect:
+		// Move to the latest next time of all timetables until they agree.
+		// A timetable which is not due at the candidate always has a later next time, so the candidate keeps moving forward.
+		for cursor, steps := after, 0; cursor.Before(limit) && steps < compositeMaxSteps; steps++ {
+			var candidate time.Time
+			for _, tt := range c.timetables {
+				t, ok := tt.Next(cursor)
+				if !ok {
+					return time.Time{}, false
+				}
+				if t.After(candidate) {
+					candidate = t
+				}
+			}
+
+			if c.IsDue(candidate) {
+				return candidate, true
+			}
+			cursor = candidate.Add(-time.Nanosecond)
+		}
+		return time.Time{}, false
+	default:
+		// Skip the whole span of a blackout, instead of every time of the base timetable during the blackout.
+		for cursor, steps := after, 0; cursor.Before(limit) && steps < compositeMaxSteps; steps++ {
+			t, ok := c.timetables[0].Next(cursor)
+			if !ok {
+				return time.Time{}, false
+			}
+
+			b := c.blackout(t)
+			if b == nil {
+				return t, true
+			}
+			_, end := span(b, t)
+			cursor = end.Add(-time.Nanosecond)
+		}
+		return time.Time{}, false
+	}
+}
+
+// Prev returns the last time before the input time at which the composite timetable was due.
+// The search is limited to searchYears before the input time and to compositeMaxSteps candidate times.
+func (c Composite) Prev(before time.Time) (time.Time, bool) {
+	if len(c.timetables) == 0 {
+		return time.Time{}, false
+	}
+
+	limit := before.AddDate(-searchYears, 0, 0)
+	switch c.operator {
+	case CompositeUnion:
+		var (
+			prev  time.Time
+			found bool
+		)
+		for _, tt := range c.timetables {
+			if t, ok := tt.Prev(before); ok && (!found || t.After(prev)) {
+				prev, found = t, true
+			}
+		}
+		return prev, found
+	case CompositeIntersect:
+		for cursor, steps := before, 0; cursor.After(limit) && steps < compositeMaxSteps; steps++ {
+			var (
+				candidate time.Time
+				found     bool
+			)
+			for _, tt := range c.timetables {
+				t, ok := tt.Prev(cursor)
+				if !ok {
+					return time.Time{}, false
+				}
+				if !found || t.Before(candidate) {
+					candidate, found = t, true
+				}
+			}
+
+			if c.IsDue(candidate) {
+				return candidate, true
+			}
+			cursor = candidate.Add(time.Nanosecond)
+		}
+		return time.Time{}, false
+	default:
+		for cursor, steps := before, 0; cursor.After(limit) && steps < compositeMaxSteps; steps++ {
+			t, ok := c.timetables[0].Prev(cursor)
+			if !ok {
+				return time.Time{}, false
+			}
+
+			b := c.blackout(t)
+			if b == nil {
+				return t, true
+			}
+			cursor, _ = span(b, t)
+		}
+		return time.Time{}, false
+	}
+}
+
+// String returns the composite timetable as an expression, which can be parsed again using Parse.
+// For example: union(*/15 * 9-17 * * MON-FRI; 0 0 3 * * *)
+func (c Composite) String() string {
+	expressions := make([]string, len(c.timetables))
+	for i, tt := range c.timetables {
+		expressions[i] = tt.String()
+	}
+	return c.operator.String() + "(" + strings.Join(expressions, compositeSeparator+" ") + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the expression of the composite as returned by String.
+// Returns an error if any of its timetables cannot be marshaled, such as a schedule with a calendar which is not registered.
+func (c Composite) MarshalText() ([]byte, error) {
+	for _, tt := range c.timetables {
+		if m, ok := tt.(encoding.TextMarshaler); ok {
+			if _, err := m.MarshalText(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return []byte(c.String()), nil
+}
+
+// blackout returns the first blackout timetable which is due at t, or nil if none is due.
+func (c Composite) blackout(t time.Time) Timetable {
+	for _, tt := range c.timetables[1:] {
+		if tt.IsDue(t) {
+			return tt
+		}
+	}
+	return nil
+}
+
+// parseComposite parses a composite expression such as union(a; b).
+// Returns false if the expression is not a composite expression.
+func parseComposite(expression string, opts ...ScheduleOption) (Composite, bool, error) {
+	for i, name := range compositeOperatorStrings {
+		if !strings.HasPrefix(expression, name+"(") {
+			continue
+		}
+
+		if !strings.HasSuffix(expression, ")") {
+			return Composite{}, true, fmt.Errorf("invalid %s expression %s, missing closing parenthesis", name, expression)
+		}
+
+		parts, err := splitComposite(expression[len(name)+1 : len(expression)-1])
+		if err != nil {
+			return Composite{}, true, fmt.Errorf("invalid %s expression %s: %w", name, expression, err)
+		}
+
+		timetables := make([]Timetable, len(parts))
+		for j, part := range parts {
+			if timetables[j], err = Parse(part, opts...); err != nil {
+				return Composite{}, true, fmt.Errorf("invalid %s expression %s: %w", name, expression, err)
+			}
+		}
+
+		return Composite{
+			operator:   CompositeOperator(i),
+			timetables: timetables,
+		}, true, nil
+	}
+	return Composite{}, false, nil
+}
+
+// span returns the start and end of the span of time around t during which tt is due.
+// Timetables which do not implement spanner are only known to be due during the second of t.
+func span(tt Timetable, t time.Time) (time.Time, time.Time) {
+	if s, ok := tt.(spanner); ok {
+		return s.span(t)
+	}
+	start := t.Truncate(time.Second)
+	return start, start.Add(time.Second)
+}
+
+// splitComposite splits the arguments of a composite expression on separators which are not nested in parentheses.
+func splitComposite(arguments string) ([]string, error) {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+
+	for i, r := range arguments {
+		switch string(r) {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses")
+			}
+		case compositeSeparator:
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(arguments[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses")
+	}
+	parts = append(parts, strings.TrimSpace(arguments[start:]))
+
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("empty timetable")
+		}
+	}
+	return parts, nil
+}
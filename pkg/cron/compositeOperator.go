@@ -0,0 +1,15 @@
+package cron
+
+const (
+	CompositeUnion     CompositeOperator = iota // due when any of the timetables is due
+	CompositeIntersect                          // due when all timetables are due
+	CompositeExcept                             // due when the first timetable is due and none of the others are
+)
+
+var compositeOperatorStrings = []string{"union", "intersect", "except"}
+
+type CompositeOperator int
+
+func (o CompositeOperator) String() string {
+	return compositeOperatorStrings[o]
+}
@@ -0,0 +1,20 @@
+package cron
+
+import "testing"
+
+func TestCompositeOperator_String(t *testing.T) {
+	var (
+		result []string
+		wanted = compositeOperatorStrings
+	)
+
+	for i := 0; i < len(wanted); i++ {
+		result = append(result, CompositeOperator(i).String())
+	}
+
+	for j := 0; j < len(wanted); j++ {
+		if result[j] != wanted[j] {
+			t.Errorf("invalid string: got %s expected %s", result[j], wanted[j])
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, expression string) Timetable {
+	t.Helper()
+
+	tt, err := Parse(expression)
+	if err != nil {
+		t.Fatalf("cannot parse %s: %v", expression, err)
+	}
+	return tt
+}
+
+func TestComposite_IsDue(t *testing.T) {
+	var tests = []struct {
+		expression string
+		time       string
+		wanted     bool
+	}{
+		{"union(0 */15 9-17 * * MON-FRI *; 0 0 3 * * * *)", "20250106091500", true},
+		{"union(0 */15 9-17 * * MON-FRI *; 0 0 3 * * * *)", "20250106030000", true},
+		{"union(0 */15 9-17 * * MON-FRI *; 0 0 3 * * * *)", "20250106180000", false},
+		{"union(0 */15 9-17 * * MON-FRI *; 0 0 3 * * * *)", "20250105091500", false},
+		{"intersect(0 0 * * * * *; 0 0 */6 * * * *)", "20250106060000", true},
+		{"intersect(0 0 * * * * *; 0 0 */6 * * * *)", "20250106070000", false},
+		{"except(0 0 * * * * *; * * 22-23 * * * *)", "20250106210000", true},
+		{"except(0 0 * * * * *; * * 22-23 * * * *)", "20250106220000", false},
+		{"except(0 0 * * * * *; * * 22-23 * * * *)", "20250106230000", false},
+		{"except(0 0 * * * * *; * * 22-23 * * * *; 0 0 0 * * * *)", "20250106000000", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+" "+tt.time, func(t *testing.T) {
+			c := mustParse(t, tt.expression)
+			at, _ := time.Parse("20060102150405", tt.time)
+			if got := c.IsDue(at); got != tt.wanted {
+				t.Errorf("IsDue(%s) = %t, wanted %t", at, got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestComposite_Next(t *testing.T) {
+	var tests = []struct {
+		expression string
+		after      string
+		wanted     string
+	}{
+		{"union(0 */15 9-17 * * MON-FRI *; 0 0 3 * * * *)", "20250106174500", "20250107030000"},
+		{"union(0 */15 9-17 * * MON-FRI *; 0 0 3 * * * *)", "20250107030000", "20250107090000"},
+		{"union(0 */15 9-17 * * MON-FRI *; @at 2025-01-07T05:00:00Z)", "20250107030000", "20250107050000"},
+		{"intersect(0 0 * * * * *; 0 0 */6 * * * *)", "20250106060000", "20250106120000"},
+		{"intersect(0 0 */2 * * * *; 0 0 */3 * * * *)", "20250106000000", "20250106060000"},
+		{"intersect(@every 1h; 0 0 */5 * * * *)", "20250106000000", "20250106050000"},
+		{"except(0 0 * * * * *; * * 22-23 * * * *)", "20250106210000", "20250107000000"},
+		{"except(@every 30m; 0 */30 0-11 * * * *)", "20250106000000", "20250106120000"},
+		{"except(* * * * * * *; * * 9-17 * * * *)", "20250106093000", "20250106180000"},
+		{"except(* * * * * * *; * 0-29 * * * * *)", "20250106091000", "20250106093000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+" "+tt.after, func(t *testing.T) {
+			c := mustParse(t, tt.expression)
+			after, _ := time.Parse("20060102150405", tt.after)
+			wanted, _ := time.Parse("20060102150405", tt.wanted)
+
+			got, ok := c.Next(after)
+			if !ok || !got.Equal(wanted) {
+				t.Errorf("Next(%s) = %s, %t, wanted %s", after, got, ok, wanted)
+			}
+		})
+	}
+}
+
+func TestComposite_Prev(t *testing.T) {
+	var tests = []struct {
+		expression string
+		before     string
+		wanted     string
+	}{
+		{"union(0 */15 9-17 * * MON-FRI *; 0 0 3 * * * *)", "20250107090000", "20250107030000"},
+		{"intersect(0 0 */2 * * * *; 0 0 */3 * * * *)", "20250106110000", "20250106060000"},
+		{"except(0 0 * * * * *; * * 22-23 * * * *)", "20250107000000", "20250106210000"},
+		{"except(* * * * * * *; * * 9-17 * * * *)", "20250106093000", "20250106085959"},
+		{"except(* * * * * * *; * 0-29 * * * * *)", "20250106091000", "20250106085959"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+" "+tt.before, func(t *testing.T) {
+			c := mustParse(t, tt.expression)
+			before, _ := time.Parse("20060102150405", tt.before)
+			wanted, _ := time.Parse("20060102150405", tt.wanted)
+
+			got, ok := c.Prev(before)
+			if !ok || !got.Equal(wanted) {
+				t.Errorf("Prev(%s) = %s, %t, wanted %s", before, got, ok, wanted)
+			}
+		})
+	}
+}
+
+func TestComposite_NextNever(t *testing.T) {
+	c := Intersect(mustParse(t, "@at 2025-01-06T10:00:00Z"), mustParse(t, "0 30 * * * * *"))
+
+	if got, ok := c.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("Next() = %s, wanted no time", got)
+	}
+}
+
+func TestComposite_NeverDue(t *testing.T) {
+	var tests = []string{
+		"except(0 * * * * *; * * * * * *)",
+		"intersect(0 0 * * * *; 0 30 * * * *)",
+		"except(* * * * * *; * * * * * *)",
+		"except(* * * * * *; * 0-29 * * * *; * 30-59 * * * *)",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt, func(t *testing.T) {
+			c := mustParse(t, tt)
+			at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			start := time.Now()
+			if got, ok := c.Next(at); ok {
+				t.Errorf("Next() = %s, wanted no time", got)
+			}
+			if got, ok := c.Prev(at); ok {
+				t.Errorf("Prev() = %s, wanted no time", got)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("Next() and Prev() took %s, wanted them to give up quickly", elapsed)
+			}
+		})
+	}
+}
+
+func TestComposite_String(t *testing.T) {
+	var tests = []struct {
+		composite Composite
+		wanted    string
+	}{
+		{Union(NewOnce(time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC))), "union(@at 2025-01-06T10:00:00Z)"},
+		{Except(Union(mustParse(t, "@every 1h"), mustParse(t, "@daily")), mustParse(t, "* * 22-23 * * * *")), "except(union(@every 1h0m0s; 0 0 * * *); * * 22-23 * * * *)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wanted, func(t *testing.T) {
+			if got := tt.composite.String(); got != tt.wanted {
+				t.Fatalf("String() = %s, wanted %s", got, tt.wanted)
+			}
+
+			if got := mustParse(t, tt.wanted).String(); got != tt.wanted {
+				t.Errorf("String() after parsing = %s, wanted %s", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestParse_Composite(t *testing.T) {
+	var tests = []struct {
+		expression string
+		success    bool
+	}{
+		{"union(* * * * *)", true},
+		{"union(* * * * *; @every 1m)", true},
+		{"except(* * * * *; intersect(0 * * * *; @every 1h))", true},
+
+		{"union()", false},
+		{"union(* * * * *", false},
+		{"union(* * * * *;)", false},
+		{"union(* * * * *; a * * * *)", false},
+		{"union(* * * * *; intersect(0 * * * *)", false},
+		{"except(* * * * *; intersect(0 * * * *)))", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := Parse(tt.expression)
+			if (err == nil) != tt.success {
+				t.Errorf("unexpected result for %s with error: %v", tt.expression, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Describe returns a description of the schedule in English, such as "At 02:30 on every weekday in March".
+func (s Schedule) Describe() string {
+	return s.DescribeIn(LocaleEnglish)
+}
+
+// DescribeIn returns a description of the schedule using the words and phrases of locale l.
+// The description covers the elements of the expression, the location and calendar of the schedule are not included.
+func (s Schedule) DescribeIn(l Locale) string {
+	if len(s.elements) == 0 {
+		return ""
+	}
+
+	var parts []string
+	parts = append(parts, s.describeTime(l)...)
+
+	day := s.element(positionDay).describe(l)
+	weekday := s.element(positionWeekday).describe(l)
+	switch {
+	case day != "" && weekday != "" && !s.strictDays && s.element(positionDay).restricted() && s.element(positionWeekday).restricted():
+		parts = append(parts, day+l.Or+weekday)
+	case day != "" && weekday != "":
+		parts = append(parts, day+l.And+weekday)
+	case day != "":
+		parts = append(parts, day)
+	case weekday != "":
+		parts = append(parts, weekday)
+	}
+
+	for _, p := range []position{positionMonth, positionYear} {
+		if d := s.element(p).describe(l); d != "" {
+			parts = append(parts, d)
+		}
+	}
+
+	description := strings.Join(parts, l.Separator)
+	r, size := utf8.DecodeRuneInString(description)
+	return string(unicode.ToUpper(r)) + description[size:]
+}
+
+// describeTime returns the parts of the description for the second, minute and hour elements.
+// If all of them have a single value, the time of day is described instead.
+func (s *Schedule) describeTime(l Locale) []string {
+	second, minute, hour := s.element(positionSecond), s.element(positionMinute), s.element(positionHour)
+	if second.single() && minute.single() && hour.single() {
+		h, _ := strconv.Atoi(hour.expression)
+		m, _ := strconv.Atoi(minute.expression)
+		clock := fmt.Sprintf("%02d:%02d", h, m)
+		if sec, _ := strconv.Atoi(second.expression); sec != 0 {
+			clock += fmt.Sprintf(":%02d", sec)
+		}
+		return []string{fmt.Sprintf(l.At, clock)}
+	}
+
+	var parts []string
+	if second.expression != "0" {
+		parts = append(parts, second.describe(l))
+	}
+	// Every minute is implied when the seconds repeat, every hour is implied by the minutes.
+	if minute.expression != "*" || second.expression == "0" || second.single() {
+		parts = append(parts, minute.describe(l))
+	}
+	if hour.expression != "*" {
+		parts = append(parts, hour.describe(l))
+	}
+	return parts
+}
+
+// describe returns the description of the element using the words and phrases of locale l.
+// Returns an empty string for wildcard elements of the day, month, weekday and year positions, as they do not restrict the schedule.
+func (e *element) describe(l Locale) string {
+	switch e.q {
+	case qualificationAny:
+		return ""
+	case qualificationLastDay:
+		return l.LastDay
+	case qualificationNearestWeekday:
+		inputs, _ := e.parseCalendarExpression()
+		if len(inputs) == 1 && inputs[0] == 0 {
+			return l.LastWeekdayOfMonth
+		}
+		return fmt.Sprintf(l.NearestWeekday, inputs[0])
+	case qualificationNthWeekday:
+		inputs, _ := e.parseCalendarExpression()
+		return fmt.Sprintf(l.NthWeekday, l.Ordinals[inputs[1]-1], e.format(l, inputs[0]))
+	case qualificationLastWeekday:
+		inputs, _ := e.parseCalendarExpression()
+		return fmt.Sprintf(l.LastWeekday, e.format(l, inputs[0]))
+	}
+
+	if e.wildcard() {
+		if e.p >= positionDay {
+			return ""
+		}
+		return l.Every[e.p]
+	}
+
+	terms, err := e.parseExpression()
+	if err != nil {
+		return e.expression
+	}
+
+	if len(terms) == 1 {
+		tm := terms[0]
+		switch {
+		case tm.start == tm.end:
+			return fmt.Sprintf(l.Value[e.p], e.format(l, tm.start))
+		case e.p == positionWeekday && tm.start == 1 && tm.end == 5 && tm.step == 1:
+			return l.EveryWeekday
+		case tm.start == e.p.min() && tm.end == e.p.max() && tm.step == 1:
+			return l.Every[e.p]
+		case tm.start == e.p.min() && tm.end == e.p.max():
+			return fmt.Sprintf(l.EveryN[e.p], tm.step)
+		case tm.step > 1:
+			return fmt.Sprintf(l.EveryNBetween[e.p], tm.step, e.format(l, tm.start), e.format(l, tm.end))
+		}
+	}
+
+	values := make([]string, len(terms))
+	for i, tm := range terms {
+		switch {
+		case tm.start == tm.end:
+			values[i] = e.format(l, tm.start)
+		case tm.step == 1:
+			values[i] = fmt.Sprintf(l.Range, e.format(l, tm.start), e.format(l, tm.end))
+		default:
+			values[i] = fmt.Sprintf(l.RangeStep, e.format(l, tm.start), e.format(l, tm.end), tm.step)
+		}
+	}
+
+	if len(values) == 1 { // a single range
+		return fmt.Sprintf(l.Values[e.p], values[0])
+	}
+	list := strings.Join(values[:len(values)-1], l.ListSeparator) + l.ListLast + values[len(values)-1]
+	return fmt.Sprintf(l.Values[e.p], list)
+}
+
+// single checks if the element matches a single value.
+func (e *element) single() bool {
+	return e.q == qualificationSimple && !e.wildcard()
+}
+
+// format returns value v of the element as a word of locale l for months and weekdays, or as a number for other positions.
+func (e *element) format(l Locale, v int) string {
+	switch e.p {
+	case positionMonth:
+		return l.Months[v-1]
+	case positionWeekday:
+		return l.Weekdays[positionWeekday.normalize(v)]
+	default:
+		return strconv.Itoa(v)
+	}
+}
@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"testing"
+)
+
+func TestSchedule_Describe(t *testing.T) {
+	var tests = []struct {
+		expression string
+		wanted     string
+	}{
+		{"0 30 2 * 3 1-5", "At 02:30 on every weekday in March"},
+		{"30 2 * 3 MON-FRI", "At 02:30 on every weekday in March"},
+		{"15 30 2 * * *", "At 02:30:15"},
+		{"* * * * *", "Every minute"},
+		{"* * * * * *", "Every second"},
+		{"*/10 * * * * *", "Every 10 seconds"},
+		{"5 * * * * *", "At second 5 every minute"},
+		{"0 * * * *", "At minute 0"},
+		{"*/15 9-17 * * MON-FRI", "Every 15 minutes at hours 9 through 17 on every weekday"},
+		{"0 0 1 * MON", "At 00:00 on day 1 of the month or on Monday"},
+		{"0 0 1,15 * *", "At 00:00 on days 1 and 15 of the month"},
+		{"0 0 */2 * *", "At 00:00 every 2 days"},
+		{"0 0 L * *", "At 00:00 on the last day of the month"},
+		{"0 0 LW * *", "At 00:00 on the last weekday of the month"},
+		{"0 0 15W * *", "At 00:00 on the weekday nearest day 15 of the month"},
+		{"0 0 * * 5L", "At 00:00 on the last Friday of the month"},
+		{"0 0 * * 1#2", "At 00:00 on the second Monday of the month"},
+		{"0 0 * * 7", "At 00:00 on Sunday"},
+		{"0 0 * * 5-7", "At 00:00 on Friday through Sunday"},
+		{"0 0 * JAN,JUN,DEC * 2030", "At 00:00 in January, June and December in 2030"},
+		{"0 0 * 3-6 *", "At 00:00 in March through June"},
+		{"0 0 12 * * ? *", "At 12:00"},
+		{"@daily", "At 00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			s, err := NewSchedule(tt.expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := s.Describe(); got != tt.wanted {
+				t.Errorf("got %q, wanted %q", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestSchedule_DescribeIn(t *testing.T) {
+	var tests = []struct {
+		expression string
+		locale     Locale
+		wanted     string
+	}{
+		{"0 30 2 * 3 1-5", LocaleEnglish, "At 02:30 on every weekday in March"},
+		{"0 30 2 * 3 1-5", LocaleDutch, "Om 02:30 op elke weekdag in maart"},
+		{"0 0 1 * MON", LocaleDutch, "Om 00:00 op dag 1 van de maand of op maandag"},
+		{"0 0 * * 1#2", LocaleDutch, "Om 00:00 op de tweede maandag van de maand"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			s, err := NewSchedule(tt.expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := s.DescribeIn(tt.locale); got != tt.wanted {
+				t.Errorf("got %q, wanted %q", got, tt.wanted)
+			}
+		})
+	}
+}
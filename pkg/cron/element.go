@@ -1,7 +1,7 @@
 package cron
 
 import (
-	"fmt"
+	"math/bits"
 	"regexp"
 	"strconv"
 	"strings"
@@ -9,22 +9,32 @@ import (
 )
 
 const (
-	validSecondOrMinute = `(?:^(?:[1-5]?\d){1}$)|(?:^(?:[1-5]?\d)(?:,(?:[1-5]?\d))+$)|(?:^(?:[1-5]?\d)-(?:[1-5]?\d)$)|(?:^(?:\*/[2-6]|\*/10|\*/12|\*/15|\*/20|\*/30)$)`
-	validHour           = `(?:^\*$^(?:(?:\d)|(?:1[0-9]{1})|(?:2[0-3]{1}))$)|(?:^(?:(?:\d)|(?:1[0-9]{1})|(?:2[0-3]{1}))(?:,(?:(?:\d)|(?:1[0-9]{1})|(?:2[0-3]{1})))*$)|(?:^(?:(?:\d)|(?:1[0-9]{1})|(?:2[0-3]{1}))-(?:(?:\d)|(?:1[0-9]{1})|(?:2[0-3]{1}))$)|(?:^(?:\*/[2-4]|\*/6|\*/8|\*/12)$)`
-	validDayOfWeek      = `(?:^(?:(?:[0-6]{1}))$)|(?:^(?:(?:[0-6]{1}))(?:,(?:(?:[0-6]{1})))*$)|(?:^(?:(?:[0-6]{1}))-(?:(?:[0-6]{1}))$)`
-	validDayOfMonth     = `(?:^(?:(?:[1-2]?[1-9]{1})|(?:3[0-1]{1}))$)|(?:^(?:(?:[1-2]?[1-9]{1})|(?:3[0-1]{1}))(?:,(?:(?:(?:[1-2]?[1-9]{1})|(?:3[0-1]{1}))))*$)|(?:^(?:(?:[1-2]?[1-9]{1})|(?:3[0-1]{1}))-(?:(?:[1-2]?[1-9]{1})|(?:3[0-1]{1}))$)`
-	validMonth          = `(?:^(?:(?:[1-9])|(?:1[0-2]{1}))$)|(?:^(?:(?:[1-9])|(?:1[0-2]{1}))(?:,(?:(?:[1-9])|(?:1[0-2]{1})))*$)|(?:^(?:(?:[1-9])|(?:1[0-2]{1}))-(?:(?:[1-9])|(?:1[0-2]{1}))$)`
-	validYear           = `(?:^\d+$)|(?:^(?:\d+)(?:,(?:\d+)+)*$)|(?:^\d+-\d+$)|(?:^\*\/\d+$)`
+	validTerm       = `(?:\*|\d+(?:-\d+)?)(?:/\d+)?`
+	validList       = `^` + validTerm + `(?:,` + validTerm + `)*$`
+	validDayOfWeek  = `^(?:\?|\d+L|\d+#\d+)$`
+	validDayOfMonth = `^(?:\?|L|LW|\d+W)$`
+	validYearTerm   = `\d{4,}(?:-\d{4,})?(?:/\d+)?`
+	validYear       = `^` + validYearTerm + `(?:,` + validYearTerm + `)*$`
 )
 
-var reSecondOrMinute = regexp.MustCompile(validSecondOrMinute)
-var reHour = regexp.MustCompile(validHour)
-var reMonth = regexp.MustCompile(validMonth)
+var reList = regexp.MustCompile(validList)
 var reDayOfWeek = regexp.MustCompile(validDayOfWeek)
 var reDayOfMonth = regexp.MustCompile(validDayOfMonth)
 var reYear = regexp.MustCompile(validYear)
 
+// wildcards contains an element matching every value for each position, used for positions missing in an expression.
+var wildcards = []element{
+	{expression: "*", p: positionSecond},
+	{expression: "*", p: positionMinute},
+	{expression: "*", p: positionHour},
+	{expression: "*", p: positionDay},
+	{expression: "*", p: positionMonth},
+	{expression: "*", p: positionWeekday},
+	{expression: "*", p: positionYear},
+}
+
 // newElement returns a new cron element based on the input expression and position.
+// The expression is compiled once, so the element can be evaluated without parsing the expression again.
 // Returns an error if the expression cannot be validated for the position.
 func newElement(expression string, position position) (element, error) {
 	e := element{
@@ -33,142 +43,312 @@ func newElement(expression string, position position) (element, error) {
 		q:          qualificationNone,
 	}
 
-	return e, e.validate() // element has pointer receivers, validate() will update the qualification on return
+	if err := e.validate(); err != nil { // element has pointer receivers, validate() will update the qualification on return
+		return e, err
+	}
+	return e, e.compile()
 }
 
 // Element specifies the expression for a specific position in the cron schedule definition.
+// Once compiled, the values of all positions except the year are stored in a bitset.
+// The year is unbounded, so its terms are stored instead.
 type element struct {
 	expression string
 	p          position
 	q          qualification
+	compiled   bool
+	bits       uint64 // bit n is set when value n matches the expression
+	terms      []term // terms for the year position
+	inputs     []int  // inputs for calendar qualifications
 }
 
-// isDue checks if the value of t matches with any of the inputs, based on the qualification of the element.
-func (e *element) isDue(t int, inputs []int) bool {
-	// Fail-fast check to make sure there is input to check against.
-	if len(inputs) == 0 {
-		return false
+// compile parses the expression of the element into a bitset, terms or inputs, depending on the position and qualification.
+// Returns an error if the expression cannot be parsed.
+func (e *element) compile() error {
+	var err error
+	switch {
+	case e.wildcard():
+	case e.isCalendar():
+		if e.inputs, err = e.parseCalendarExpression(); err != nil {
+			return err
+		}
+	case e.p == positionYear:
+		if e.terms, err = e.parseExpression(); err != nil {
+			return err
+		}
+	default:
+		var terms []term
+		if terms, err = e.parseExpression(); err != nil {
+			return err
+		}
+		for _, tm := range terms {
+			for v := max(tm.start, 0); v <= tm.end && v < 64; v += tm.step {
+				e.bits |= 1 << e.p.normalize(v)
+			}
+		}
 	}
+	e.compiled = true
+	return nil
+}
 
-	switch e.q {
-	case qualificationSimple: // only a single value is possible for the input
-		if inputs[0] == t {
+// isDue checks if the value of t matches with any of the terms of the element.
+func (e *element) isDue(t int, terms []term) bool {
+	for _, tm := range terms {
+		if tm.contains(t) {
 			return true
 		}
-		return false
-	case qualificationMulti: // multiple values are possible, we can match on any input value
-		for _, i := range inputs {
-			if i == t {
-				return true
-			}
+	}
+	return false
+}
+
+// isDueOn checks if the date of t matches the inputs, for qualifications that depend on the calendar rather than on a single value.
+func (e *element) isDueOn(t time.Time, inputs []int) bool {
+	days := daysIn(t.Year(), t.Month())
+
+	switch e.q {
+	case qualificationLastDay: // last day of the month
+		return t.Day() == days
+	case qualificationNearestWeekday: // weekday nearest to the input day, without leaving the month
+		if len(inputs) == 0 {
+			return false
 		}
-		return false
-	case qualificationRange: // input must be in a range between two input values
-		if t >= inputs[0] && t <= inputs[1] {
-			return true
+		return t.Day() == nearestWeekday(t.Year(), t.Month(), inputs[0])
+	case qualificationNthWeekday: // nth occurrence of the input weekday in the month
+		if len(inputs) != 2 {
+			return false
 		}
-		return false
-	case qualificationStep: // value must return 0 when performing a mod division by the input
-		if t%inputs[0] == 0 {
-			return true
+		return int(t.Weekday()) == inputs[0] && (t.Day()-1)/7+1 == inputs[1]
+	case qualificationLastWeekday: // last occurrence of the input weekday in the month
+		if len(inputs) == 0 {
+			return false
 		}
+		return int(t.Weekday()) == inputs[0] && t.Day()+7 > days
+	default:
 		return false
+	}
+}
+
+// isCalendar checks if the element has a qualification that depends on the calendar rather than on a single value.
+func (e *element) isCalendar() bool {
+	switch e.q {
+	case qualificationLastDay, qualificationNearestWeekday, qualificationNthWeekday, qualificationLastWeekday:
+		return true
 	default:
 		return false
 	}
 }
 
-// parseExpression parses the expression into an array of int which can be used to check if the element is due.
-// An error will be returned if the value of the expression cannot be parsed into an array of int.
-func (e *element) parseExpression() ([]int, error) {
+// parseCalendarExpression parses the expression of an element with a calendar qualification into an array of int.
+// An error will be returned if the expression cannot be parsed, or if the values are out of range for the position.
+func (e *element) parseCalendarExpression() ([]int, error) {
 	var (
 		s      []string
 		output []int
 		err    error
 	)
 
-	// No parsing must be done
-	if e.expression == "*" {
-		return output, nil
-	}
-
-	// Parsing depends on the qualification of the element
 	switch e.q {
-	case qualificationNone: // validate the expression in case the element hasn't got a proper qualification
-		if err = e.validate(); err != nil {
-			return nil, err
-		}
-		return e.parseExpression()
-	case qualificationSimple: // parsing should return a single value
+	case qualificationLastDay: // expression has no values
+		return output, nil
+	case qualificationNearestWeekday: // expression has a single day followed by W, or LW for the last day of the month
 		output = make([]int, 1)
-		if output[0], err = strconv.Atoi(e.expression); err != nil {
-			return output, err
+		if e.expression == "LW" {
+			return output, nil
 		}
-	case qualificationMulti: // expression has multiple values separated by a comma
-		s = strings.Split(e.expression, ",")
-		output = make([]int, len(s))
-		for k, v := range s {
-			if output[k], err = strconv.Atoi(v); err != nil {
-				return output, err
-			}
+		if output[0], err = strconv.Atoi(strings.TrimSuffix(e.expression, "W")); err != nil {
+			return output, newParseError(e.p, ReasonUnknownLiteral, "invalid expression %s in %s", e.expression, e.p.String())
+		}
+		if output[0] < e.p.min() || output[0] > e.p.max() {
+			return output, newParseError(e.p, ReasonOutOfRange, "value %d out of range in %s", output[0], e.p.String())
+		}
+	case qualificationNthWeekday: // expression has a weekday and an occurrence separated by a hash
+		s = strings.Split(e.expression, "#")
+		if len(s) != 2 {
+			return output, newParseError(e.p, ReasonUnknownLiteral, "expression %s must have a weekday and an occurrence", e.expression)
 		}
-	case qualificationRange: // expression defines a ranges of values between two numbers
-		s = strings.Split(e.expression, "-")
 		output = make([]int, 2)
 		for k, v := range s {
 			if output[k], err = strconv.Atoi(v); err != nil {
-				return output, err
+				return output, newParseError(e.p, ReasonUnknownLiteral, "invalid expression %s in %s", e.expression, e.p.String())
 			}
 		}
-	case qualificationStep:
+		if output[0] < e.p.min() || output[0] > e.p.limit() {
+			return output, newParseError(e.p, ReasonOutOfRange, "value %d out of range in %s", output[0], e.p.String())
+		}
+		output[0] = e.p.normalize(output[0])
+		if output[1] < 1 || output[1] > 5 {
+			return output, newParseError(e.p, ReasonOutOfRange, "occurrence %d out of range in %s", output[1], e.p.String())
+		}
+	case qualificationLastWeekday: // expression has a single weekday followed by L
 		output = make([]int, 1)
-		s = strings.Split(e.expression, "*/")
-		if e.expression == s[0] {
-			return output, fmt.Errorf("expression %s does not start with */", e.expression)
+		if output[0], err = strconv.Atoi(strings.TrimSuffix(e.expression, "L")); err != nil {
+			return output, newParseError(e.p, ReasonUnknownLiteral, "invalid expression %s in %s", e.expression, e.p.String())
+		}
+		if output[0] < e.p.min() || output[0] > e.p.limit() {
+			return output, newParseError(e.p, ReasonOutOfRange, "value %d out of range in %s", output[0], e.p.String())
+		}
+		output[0] = e.p.normalize(output[0])
+	default:
+		return output, newParseError(e.p, ReasonUnknownLiteral, "expression %s is not a calendar expression", e.expression)
+	}
+	return output, nil
+}
+
+// parseExpression parses the expression into an array of terms which can be used to check if the element is due.
+// The expression is a comma-separated list of terms, each term being a single value, a range or a wildcard, optionally followed by a step.
+// An error will be returned if the expression cannot be parsed into an array of terms, or if the terms are not in ascending order.
+func (e *element) parseExpression() ([]term, error) {
+	var (
+		output []term
+		err    error
+	)
+
+	// No parsing must be done
+	if e.wildcard() {
+		return output, nil
+	}
+
+	// validate the expression in case the element hasn't got a proper qualification
+	if e.q == qualificationNone {
+		if err = e.validate(); err != nil {
+			return nil, err
 		}
+	}
 
-		// Error handling is not required, as qualification is already parsed and step is an int
-		if output[0], err = strconv.Atoi(s[1]); err != nil {
+	s := strings.Split(e.expression, ",")
+	output = make([]term, len(s))
+	for k, v := range s {
+		if output[k], err = parseTerm(v, e.p); err != nil {
 			return output, err
 		}
 	}
 
-	// If there are multiple values, check if they are ascending
-	if len(output) > 1 {
-		for i := 0; i < len(output)-1; i++ {
-			if output[i] > output[i+1] {
-				return output, fmt.Errorf("invalid order of values in %s", e.p.String())
-			}
+	// If there are multiple terms, check if they are ascending
+	for i := 0; i < len(output)-1; i++ {
+		if output[i].start > output[i+1].start {
+			return output, newParseError(e.p, ReasonUnorderedList, "invalid order of values in %s", e.p.String())
 		}
 	}
 	return output, nil
 }
 
-// qualify takes the expression for the element and detects the qualification (simple, multi, range, step)
+// qualify takes the expression for the element and detects the qualification (simple, multi, range, step).
+// Expressions using the special characters ?, L, W and # are qualified first, as they cannot be combined with other qualifications.
 func (e *element) qualify() {
-	if !strings.ContainsAny(e.expression, strings.Join(qualificationChars, "")) {
+	switch {
+	case e.expression == "?":
+		e.q = qualificationAny
+	case e.expression == "L":
+		e.q = qualificationLastDay
+	case strings.HasSuffix(e.expression, "W"):
+		e.q = qualificationNearestWeekday
+	case strings.Contains(e.expression, "#"):
+		e.q = qualificationNthWeekday
+	case strings.HasSuffix(e.expression, "L"):
+		e.q = qualificationLastWeekday
+	case strings.Contains(e.expression, ","):
+		e.q = qualificationMulti
+	case strings.Contains(e.expression, "/"):
+		e.q = qualificationStep
+	case strings.Contains(e.expression, "-"):
+		e.q = qualificationRange
+	default:
 		e.q = qualificationSimple
-		return
 	}
+}
 
-	for i, char := range qualificationChars {
-		if strings.Contains(e.expression, char) {
-			// offset qualification for complex expressions, starts at i=2: qualificationMulti
-			e.q = qualification(i + 2)
-			return
+// match checks if value v aligns with the expression for the element.
+func (e *element) match(v int) bool {
+	switch {
+	case e.wildcard():
+		return true
+	case !e.compiled: // elements which are not created using newElement must be parsed for every evaluation
+		terms, err := e.parseExpression()
+		if err != nil {
+			return false
+		}
+		if e.p == positionWeekday && v == 0 && e.isDue(7, terms) { // weekday 7 is Sunday
+			return true
 		}
+		return e.isDue(v, terms)
+	case e.p == positionYear:
+		return e.isDue(v, e.terms)
+	default:
+		return v >= 0 && v < 64 && e.bits&(1<<v) != 0
 	}
 }
 
+// next returns the first value between v and limit (inclusive) that aligns with the expression for the element.
+// Returns false if no such value exists.
+func (e *element) next(v int, limit int) (int, bool) {
+	switch {
+	case e.wildcard():
+		return v, v <= limit
+	case e.compiled && e.p == positionYear:
+		output, found := 0, false
+		for _, tm := range e.terms {
+			if n, ok := tm.next(v); ok && n <= limit && (!found || n < output) {
+				output, found = n, true
+			}
+		}
+		return output, found
+	case e.compiled && v >= 0 && v < 64:
+		if n := bits.TrailingZeros64(e.bits >> v << v); n <= limit && n < 64 {
+			return n, true
+		}
+		return 0, false
+	}
+
+	for ; v <= limit; v++ {
+		if e.match(v) {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// prev returns the last value between limit and v (inclusive) that aligns with the expression for the element.
+// Returns false if no such value exists.
+func (e *element) prev(v int, limit int) (int, bool) {
+	switch {
+	case e.wildcard():
+		return v, v >= limit
+	case e.compiled && e.p == positionYear:
+		output, found := 0, false
+		for _, tm := range e.terms {
+			if n, ok := tm.prev(v); ok && n >= limit && (!found || n > output) {
+				output, found = n, true
+			}
+		}
+		return output, found
+	case e.compiled && v >= 0 && v < 64:
+		if n := 63 - bits.LeadingZeros64(e.bits<<(63-v)>>(63-v)); n >= limit && n >= 0 {
+			return n, true
+		}
+		return 0, false
+	}
+
+	for ; v >= limit; v-- {
+		if e.match(v) {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
 // trigger checks if input t aligns with the expression for the element, depending on the position and qualification of the element.
 func (e *element) trigger(t time.Time) bool {
-	if e.expression == "*" {
+	switch {
+	case e.wildcard():
 		return true
-	}
-
-	intervals, err := e.parseExpression()
-	if err != nil {
-		return false
+	case e.isCalendar() && e.compiled:
+		return e.isDueOn(t, e.inputs)
+	case e.isCalendar():
+		inputs, err := e.parseCalendarExpression()
+		if err != nil {
+			return false
+		}
+		return e.isDueOn(t, inputs)
 	}
 
 	var input int
@@ -189,7 +369,7 @@ func (e *element) trigger(t time.Time) bool {
 		input = t.Year()
 	}
 
-	return e.isDue(input, intervals)
+	return e.match(input)
 }
 
 // validate ensures that the element has a valid expression set for its position.
@@ -212,15 +392,28 @@ func (e *element) validate() error {
 		return err
 	}
 
-	// No additional validation is necessary for simple expressions
-	if e.q == qualificationSimple {
+	switch {
+	case e.q == qualificationAny:
 		return nil
+	case e.isCalendar():
+		_, err = e.parseCalendarExpression()
+		return err
 	}
 
-	// There can be no mixture of different qualifications as these are imposed by regex
-	if _, err = e.parseExpression(); err != nil {
+	var terms []term
+	if terms, err = e.parseExpression(); err != nil {
 		return err
 	}
+
+	// Make sure all terms are within the range of the position
+	for _, tm := range terms {
+		if tm.start < e.p.min() || tm.end > e.p.limit() {
+			return newParseError(e.p, ReasonOutOfRange, "value out of range in %s", e.p.String())
+		}
+		if tm.step > e.p.max()-e.p.min()+1 {
+			return newParseError(e.p, ReasonBadStep, "step %d out of range in %s", tm.step, e.p.String())
+		}
+	}
 	return nil
 }
 
@@ -229,25 +422,63 @@ func (e *element) validate() error {
 func (e *element) validateExpressionForPosition() error {
 	var s []string
 	switch e.p {
-	case positionSecond:
-		s = reSecondOrMinute.FindStringSubmatch(e.expression)
-	case positionMinute:
-		s = reSecondOrMinute.FindStringSubmatch(e.expression)
-	case positionHour:
-		s = reHour.FindStringSubmatch(e.expression)
 	case positionDay:
-		s = reDayOfMonth.FindStringSubmatch(e.expression)
-	case positionMonth:
-		s = reMonth.FindStringSubmatch(e.expression)
+		if s = reDayOfMonth.FindStringSubmatch(e.expression); len(s) != 0 {
+			return nil
+		}
 	case positionWeekday:
-		s = reDayOfWeek.FindStringSubmatch(e.expression)
-	case positionYear:
-		s = reYear.FindStringSubmatch(e.expression)
+		if s = reDayOfWeek.FindStringSubmatch(e.expression); len(s) != 0 {
+			return nil
+		}
 	}
+	s = reList.FindStringSubmatch(e.expression)
 
 	// No match found
 	if len(s) == 0 {
-		return fmt.Errorf("invalid expression in %s", e.p.String())
+		return newParseError(e.p, ReasonUnknownLiteral, "invalid expression in %s", e.p.String())
 	}
 	return nil
 }
+
+// restricted checks if the element limits the values it matches.
+// As in Vixie cron, an element starting with * is not restricted, even when it has a step.
+func (e *element) restricted() bool {
+	return !strings.HasPrefix(e.expression, "*") && e.expression != "?"
+}
+
+// wildcard checks if the element matches every value.
+func (e *element) wildcard() bool {
+	return e.expression == "*" || e.expression == "?"
+}
+
+// daysIn returns the number of days in the month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekday returns the weekday (Monday to Friday) nearest to day in the month, without leaving the month.
+// Day 0 refers to the last day of the month. Returns 0 if the day does not exist in the month.
+func nearestWeekday(year int, month time.Month, day int) int {
+	days := daysIn(year, month)
+	if day == 0 {
+		day = days
+	}
+	if day > days {
+		return 0
+	}
+
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == days {
+			return day - 2
+		}
+		return day + 1
+	default:
+		return day
+	}
+}
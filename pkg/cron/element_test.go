@@ -30,8 +30,17 @@ func TestNewElement_SecondMinuteValid(t *testing.T) {
 		{"0-1"},
 		{"11-59"},
 
+		{"*/1"},
 		{"*/2"},
+		{"*/7"},
 		{"*/30"},
+		{"*/31"},
+
+		{"0-30/5"},
+		{"10/15"},
+		{"10-59/15"},
+		{"1-5,10-20/2"},
+		{"0,*/15,59"},
 	}
 
 	for _, tt := range tests {
@@ -66,11 +75,16 @@ func TestNewElement_SecondMinuteInvalid(t *testing.T) {
 		{"36-1"},
 
 		{"*/0"},
-		{"*/1"},
-		{"*/31"},
+		{"*/61"},
+		{"0-30/0"},
+		{"30-10/5"},
+		{"0-60/5"},
+		{"20-30/5,1-5"},
 
 		{"#"},
 		{"*/a"},
+		{"1-5/"},
+		{"1/2/3"},
 	}
 
 	for _, tt := range tests {
@@ -103,12 +117,19 @@ func TestNewElement_HourValid(t *testing.T) {
 		{"5-8"},
 		{"15-22"},
 
+		{"*/1"},
 		{"*/2"},
 		{"*/3"},
 		{"*/4"},
 		{"*/6"},
 		{"*/8"},
+		{"*/9"},
 		{"*/12"},
+		{"*/13"},
+
+		{"8-18/2"},
+		{"6/6"},
+		{"0-5,9-17/4,22"},
 	}
 
 	for _, tt := range tests {
@@ -140,9 +161,8 @@ func TestNewElement_HourInvalid(t *testing.T) {
 		{"6-1"},
 
 		{"*/0"},
-		{"*/1"},
-		{"*/9"},
-		{"*/13"},
+		{"*/25"},
+		{"8-24/2"},
 	}
 
 	for _, tt := range tests {
@@ -173,6 +193,18 @@ func TestNewElement_DayValid(t *testing.T) {
 		{"1-6"},
 		{"5-8"},
 		{"15-31"},
+
+		{"10"},
+		{"20"},
+		{"30"},
+		{"10,20,30"},
+		{"10-20"},
+
+		{"*/1"},
+		{"*/9"},
+		{"*/13"},
+		{"1-15/2"},
+		{"10/10"},
 	}
 
 	for _, tt := range tests {
@@ -205,9 +237,8 @@ func TestNewElement_DayInvalid(t *testing.T) {
 		{"6-1"},
 
 		{"*/0"},
-		{"*/1"},
-		{"*/9"},
-		{"*/13"},
+		{"*/32"},
+		{"0-10/2"},
 	}
 
 	for _, tt := range tests {
@@ -235,6 +266,12 @@ func TestNewElement_MonthValid(t *testing.T) {
 		{"1-6"},
 		{"5-8"},
 		{"6-12"},
+
+		{"10"},
+		{"*/1"},
+		{"*/3"},
+		{"*/9"},
+		{"1-12/2"},
 	}
 
 	for _, tt := range tests {
@@ -267,9 +304,8 @@ func TestNewElement_MonthInvalid(t *testing.T) {
 		{"6-1"},
 
 		{"*/0"},
-		{"*/1"},
-		{"*/9"},
 		{"*/13"},
+		{"0-12/2"},
 	}
 
 	for _, tt := range tests {
@@ -290,12 +326,20 @@ func TestNewElement_WeekdayValid(t *testing.T) {
 		{"0"},
 		{"5"},
 		{"6"},
+		{"7"},
 
 		{"1,4"},
 		{"0,6"},
 
 		{"1-3"},
 		{"0-6"},
+		{"5-7"},
+
+		{"*/1"},
+		{"*/2"},
+		{"1-5/2"},
+		{"0,1-5/2"},
+		{"1-7/2"},
 	}
 
 	for _, tt := range tests {
@@ -315,7 +359,7 @@ func TestNewElement_WeekdayInvalid(t *testing.T) {
 		{""},
 		{"a"},
 
-		{"7"},
+		{"8"},
 		{"105"},
 
 		{"0,10"},
@@ -325,9 +369,9 @@ func TestNewElement_WeekdayInvalid(t *testing.T) {
 		{"6-1"},
 
 		{"*/0"},
-		{"*/1"},
 		{"*/9"},
 		{"*/13"},
+		{"1-8/2"},
 	}
 
 	for _, tt := range tests {
@@ -481,30 +525,29 @@ func TestElement_parseExpressionInvalid(t *testing.T) {
 
 func TestElement_isDueValid(t *testing.T) {
 	var tests = []struct {
-		q      qualification
-		inputs []int
-		t      int
+		terms []term
+		t     int
 	}{
-		{qualificationSimple, []int{0}, 0},
-		{qualificationSimple, []int{200}, 200},
+		{[]term{{0, 0, 1}}, 0},
+		{[]term{{200, 200, 1}}, 200},
 
-		{qualificationMulti, []int{0, 20, 31, 100}, 0},
-		{qualificationMulti, []int{0, 20, 31, 100}, 20},
-		{qualificationMulti, []int{0, 20, 31, 100}, 31},
-		{qualificationMulti, []int{0, 20, 31, 100}, 100},
+		{[]term{{0, 0, 1}, {20, 20, 1}, {31, 31, 1}, {100, 100, 1}}, 0},
+		{[]term{{0, 0, 1}, {20, 20, 1}, {31, 31, 1}, {100, 100, 1}}, 20},
+		{[]term{{0, 0, 1}, {20, 20, 1}, {31, 31, 1}, {100, 100, 1}}, 31},
+		{[]term{{0, 0, 1}, {20, 20, 1}, {31, 31, 1}, {100, 100, 1}}, 100},
 
-		{qualificationRange, []int{0, 200}, 0},
-		{qualificationRange, []int{0, 200}, 31},
+		{[]term{{0, 200, 1}}, 0},
+		{[]term{{0, 200, 1}}, 31},
 
-		{qualificationStep, []int{2}, 60},
+		{[]term{{0, 200, 2}}, 60},
+		{[]term{{10, 59, 15}}, 40},
+		{[]term{{1, 5, 1}, {10, 20, 2}}, 14},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.q.String()+"_"+strconv.Itoa(tt.t), func(t *testing.T) {
-			e := element{
-				q: tt.q,
-			}
-			if !e.isDue(tt.t, tt.inputs) {
+		t.Run(strconv.Itoa(tt.t), func(t *testing.T) {
+			e := element{}
+			if !e.isDue(tt.t, tt.terms) {
 				t.Errorf("expected true for %d", tt.t)
 			}
 		})
@@ -513,34 +556,27 @@ func TestElement_isDueValid(t *testing.T) {
 
 func TestElement_isDueInvalid(t *testing.T) {
 	var tests = []struct {
-		q      qualification
-		inputs []int
-		t      int
+		terms []term
+		t     int
 	}{
-		{qualificationNone, nil, 0},
-		{qualificationNone, []int{0}, 0},
+		{nil, 0},
+		{[]term{}, 200},
+		{[]term{{0, 0, 1}}, 200},
 
-		{qualificationSimple, nil, 0},
-		{qualificationSimple, []int{}, 200},
-		{qualificationSimple, []int{0}, 200},
+		{[]term{{0, 0, 1}, {10, 10, 1}}, 200},
+		{[]term{{0, 0, 1}, {10, 10, 1}, {300, 300, 1}}, 200},
 
-		{qualificationMulti, nil, 0},
-		{qualificationMulti, []int{0, 10}, 200},
-		{qualificationMulti, []int{0, 10, 300}, 200},
+		{[]term{{0, 10, 1}}, 200},
 
-		{qualificationRange, nil, 0},
-		{qualificationRange, []int{0, 10}, 200},
-		{qualificationRange, []int{0, 10, 300}, 200},
-
-		{qualificationStep, []int{7}, 60},
+		{[]term{{0, 200, 7}}, 60},
+		{[]term{{10, 59, 15}}, 45},
+		{[]term{{1, 5, 1}, {10, 20, 2}}, 15},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.q.String()+"_"+strconv.Itoa(tt.t), func(t *testing.T) {
-			e := element{
-				q: tt.q,
-			}
-			if e.isDue(tt.t, tt.inputs) {
+		t.Run(strconv.Itoa(tt.t), func(t *testing.T) {
+			e := element{}
+			if e.isDue(tt.t, tt.terms) {
 				t.Errorf("expected false for %d", tt.t)
 			}
 		})
@@ -659,3 +695,242 @@ func TestElement_TriggerInvalid2(t *testing.T) {
 		})
 	}
 }
+
+func TestElement_Next(t *testing.T) {
+	var tests = []struct {
+		p          position
+		expression string
+		v          int
+		limit      int
+		wanted     int
+		ok         bool
+	}{
+		{positionSecond, "*", 5, 59, 5, true},
+		{positionSecond, "10", 5, 59, 10, true},
+		{positionSecond, "10", 11, 59, 0, false},
+		{positionMinute, "5,20,40", 21, 59, 40, true},
+		{positionHour, "8-17", 3, 23, 8, true},
+		{positionHour, "8-17", 18, 23, 0, false},
+		{positionMinute, "*/15", 16, 59, 30, true},
+		{positionYear, "2030", 2026, 2126, 2030, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.p.String()+"_"+tt.expression+"_"+strconv.Itoa(tt.v), func(t *testing.T) {
+			e, err := newElement(tt.expression, tt.p)
+			if err != nil {
+				t.Fatalf("invalid element for %s with expression %s", tt.p.String(), tt.expression)
+			}
+
+			v, ok := e.next(tt.v, tt.limit)
+			if ok != tt.ok || v != tt.wanted {
+				t.Errorf("got %d (%t), expected %d (%t)", v, ok, tt.wanted, tt.ok)
+			}
+		})
+	}
+}
+
+func TestElement_Prev(t *testing.T) {
+	var tests = []struct {
+		p          position
+		expression string
+		v          int
+		limit      int
+		wanted     int
+		ok         bool
+	}{
+		{positionSecond, "*", 5, 0, 5, true},
+		{positionSecond, "10", 15, 0, 10, true},
+		{positionSecond, "10", 9, 0, 0, false},
+		{positionMinute, "5,20,40", 39, 0, 20, true},
+		{positionHour, "8-17", 23, 0, 17, true},
+		{positionHour, "8-17", 7, 0, 0, false},
+		{positionMinute, "*/15", 44, 0, 30, true},
+		{positionYear, "2020", 2026, 1926, 2020, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.p.String()+"_"+tt.expression+"_"+strconv.Itoa(tt.v), func(t *testing.T) {
+			e, err := newElement(tt.expression, tt.p)
+			if err != nil {
+				t.Fatalf("invalid element for %s with expression %s", tt.p.String(), tt.expression)
+			}
+
+			v, ok := e.prev(tt.v, tt.limit)
+			if ok != tt.ok || v != tt.wanted {
+				t.Errorf("got %d (%t), expected %d (%t)", v, ok, tt.wanted, tt.ok)
+			}
+		})
+	}
+}
+
+func TestNewElement_QuartzValid(t *testing.T) {
+	var tests = []struct {
+		p          position
+		expression string
+		q          qualification
+	}{
+		{positionDay, "?", qualificationAny},
+		{positionDay, "L", qualificationLastDay},
+		{positionDay, "LW", qualificationNearestWeekday},
+		{positionDay, "1W", qualificationNearestWeekday},
+		{positionDay, "15W", qualificationNearestWeekday},
+		{positionDay, "31W", qualificationNearestWeekday},
+
+		{positionWeekday, "?", qualificationAny},
+		{positionWeekday, "5L", qualificationLastWeekday},
+		{positionWeekday, "0L", qualificationLastWeekday},
+		{positionWeekday, "2#2", qualificationNthWeekday},
+		{positionWeekday, "6#5", qualificationNthWeekday},
+		{positionWeekday, "7L", qualificationLastWeekday},
+		{positionWeekday, "7#1", qualificationNthWeekday},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.p.String()+"_"+tt.expression, func(t *testing.T) {
+			e, err := newElement(tt.expression, tt.p)
+			if err != nil {
+				t.Fatalf("invalid element, got %s with error %s", e.q.String(), err.Error())
+			}
+
+			if e.q != tt.q {
+				t.Errorf("got qualification %s, expected %s", e.q.String(), tt.q.String())
+			}
+		})
+	}
+}
+
+func TestNewElement_QuartzInvalid(t *testing.T) {
+	var tests = []struct {
+		p          position
+		expression string
+	}{
+		{positionSecond, "?"},
+		{positionHour, "L"},
+		{positionMonth, "?"},
+
+		{positionDay, "W"},
+		{positionDay, "0W"},
+		{positionDay, "32W"},
+		{positionDay, "5L"},
+		{positionDay, "2#2"},
+		{positionDay, "L5"},
+
+		{positionWeekday, "L"},
+		{positionWeekday, "8L"},
+		{positionWeekday, "2#0"},
+		{positionWeekday, "2#6"},
+		{positionWeekday, "8#1"},
+		{positionWeekday, "2#"},
+		{positionWeekday, "15W"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.p.String()+"_"+tt.expression, func(t *testing.T) {
+			e, err := newElement(tt.expression, tt.p)
+			if err == nil {
+				t.Errorf("invalid %s, got invalid expression %s", e.p.String(), e.expression)
+			}
+		})
+	}
+}
+
+func TestElement_TriggerQuartz(t *testing.T) {
+	var tests = []struct {
+		p          position
+		expression string
+		time       string
+		wanted     bool
+	}{
+		{positionDay, "L", "20060131", true},
+		{positionDay, "L", "20060130", false},
+		{positionDay, "L", "20060228", true},
+		{positionDay, "L", "20080228", false},
+		{positionDay, "L", "20080229", true},
+
+		// 2006-04-15 is a Saturday, 2006-01-15 is a Sunday, 2006-04-01 is a Saturday, 2006-04-30 is a Sunday
+		{positionDay, "15W", "20060414", true},
+		{positionDay, "15W", "20060415", false},
+		{positionDay, "15W", "20060116", true},
+		{positionDay, "15W", "20060615", true},
+		{positionDay, "1W", "20060403", true},
+		{positionDay, "1W", "20060331", false},
+		{positionDay, "LW", "20060428", true},
+		{positionDay, "LW", "20060430", false},
+		{positionDay, "31W", "20060630", false},
+
+		{positionWeekday, "2#2", "20060110", true},
+		{positionWeekday, "2#2", "20060103", false},
+		{positionWeekday, "2#2", "20060111", false},
+		{positionWeekday, "5L", "20060127", true},
+		{positionWeekday, "5L", "20060120", false},
+		{positionWeekday, "?", "20060120", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.p.String()+"_"+tt.expression+"_"+tt.time, func(t *testing.T) {
+			e, err := newElement(tt.expression, tt.p)
+			if err != nil {
+				t.Fatalf("invalid element for %s with expression %s", tt.p.String(), tt.expression)
+			}
+
+			i, _ := time.Parse("20060102", tt.time)
+			if e.trigger(i) != tt.wanted {
+				t.Errorf("expected %t for value %s with expression %s", tt.wanted, tt.time, tt.expression)
+			}
+		})
+	}
+}
+
+func TestElement_Compile(t *testing.T) {
+	var tests = []struct {
+		p          position
+		expression string
+	}{
+		{positionSecond, "*/7"},
+		{positionMinute, "0-30/5,45,50-59"},
+		{positionHour, "8-18/2"},
+		{positionDay, "10/10"},
+		{positionMonth, "1-12/3"},
+		{positionWeekday, "1-5"},
+		{positionYear, "2000-2100/4,2200"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.p.String()+"_"+tt.expression, func(t *testing.T) {
+			compiled, err := newElement(tt.expression, tt.p)
+			if err != nil {
+				t.Fatalf("invalid element for %s with expression %s", tt.p.String(), tt.expression)
+			}
+
+			parsed := element{expression: tt.expression, p: tt.p}
+			for v := tt.p.min(); v <= min(tt.p.max(), 2300); v++ {
+				if compiled.match(v) != parsed.match(v) {
+					t.Errorf("compiled element differs from parsed element for %d", v)
+				}
+
+				cn, cok := compiled.next(v, tt.p.max())
+				pn, pok := parsed.next(v, tt.p.max())
+				if cn != pn || cok != pok {
+					t.Errorf("next for %d: compiled %d (%t), parsed %d (%t)", v, cn, cok, pn, pok)
+				}
+
+				cp, cok := compiled.prev(v, tt.p.min())
+				pp, pok := parsed.prev(v, tt.p.min())
+				if cp != pp || cok != pok {
+					t.Errorf("prev for %d: compiled %d (%t), parsed %d (%t)", v, cp, cok, pp, pok)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkElement_Match(b *testing.B) {
+	e, _ := newElement("0-30/3,45", positionMinute)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		e.match(n % 60)
+	}
+}
@@ -0,0 +1,153 @@
+package cron
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{
+		now: now,
+	}
+	c.cond = sync.NewCond(&c.mux)
+	return c
+}
+
+// FakeClock is a Clock which only moves when it is advanced, to run tests without waiting in real time.
+// Timers fire when the clock is advanced past their deadline, in the order of their deadlines.
+type FakeClock struct {
+	now    time.Time
+	timers []*fakeTimer // timers waiting to fire, fired and stopped timers are removed
+	cond   *sync.Cond
+	mux    sync.Mutex
+}
+
+// Advance moves the clock forward by d, firing the timers with a deadline up to the new time.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	target := c.now.Add(d)
+	for {
+		var first *fakeTimer
+		for _, t := range c.timers {
+			if !t.deadline.After(target) && (first == nil || t.deadline.Before(first.deadline)) {
+				first = t
+			}
+		}
+		if first == nil {
+			break
+		}
+
+		if first.deadline.After(c.now) {
+			c.now = first.deadline
+		}
+		first.fire(c.now)
+	}
+
+	if target.After(c.now) {
+		c.now = target
+	}
+	c.cond.Broadcast()
+}
+
+// NewTimer returns a Timer which fires when the clock is advanced by d.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{
+		clock: c,
+		ch:    make(chan time.Time, 1),
+	}
+	t.Reset(d)
+	return t
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.now
+}
+
+// Sleep blocks until the clock is advanced by d.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.NewTimer(d).C()
+}
+
+// Timers returns the number of timers waiting to fire, including sleeps.
+func (c *FakeClock) Timers() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return len(c.timers)
+}
+
+// WaitForTimers blocks until at least n timers are waiting to fire, including sleeps.
+// Use it before advancing the clock, to make sure the goroutines under test are waiting for the clock.
+func (c *FakeClock) WaitForTimers(n int) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// removeTimer removes timer t from the timers waiting to fire; the caller must hold the lock.
+func (c *FakeClock) removeTimer(t *fakeTimer) {
+	c.timers = slices.DeleteFunc(c.timers, func(ft *fakeTimer) bool { return ft == t })
+}
+
+// fakeTimer is a Timer created by a FakeClock.
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	active   bool
+	ch       chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mux.Lock()
+	defer t.clock.mux.Unlock()
+
+	wasActive := t.active
+	t.deadline = t.clock.now.Add(d)
+	switch {
+	case d <= 0:
+		t.fire(t.clock.now)
+	case !wasActive:
+		t.active = true
+		t.clock.timers = append(t.clock.timers, t)
+	}
+	t.clock.cond.Broadcast()
+	return wasActive
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mux.Lock()
+	defer t.clock.mux.Unlock()
+
+	wasActive := t.active
+	if wasActive {
+		t.active = false
+		t.clock.removeTimer(t)
+	}
+	t.clock.cond.Broadcast()
+	return wasActive
+}
+
+// fire sends now on the channel of the timer, unless a previous time was not received yet; the caller must hold the lock.
+func (t *fakeTimer) fire(now time.Time) {
+	if t.active {
+		t.active = false
+		t.clock.removeTimer(t)
+	}
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
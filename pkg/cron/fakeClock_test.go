@@ -0,0 +1,154 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock_Advance(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	late := clock.NewTimer(2 * time.Minute)
+	early := clock.NewTimer(time.Minute)
+	stopped := clock.NewTimer(time.Minute)
+	if !stopped.Stop() {
+		t.Errorf("stopping an active timer should return true")
+	}
+
+	if clock.Timers() != 2 {
+		t.Fatalf("clock should have 2 timers, received %d", clock.Timers())
+	}
+
+	clock.Advance(90 * time.Second)
+	if !clock.Now().Equal(start.Add(90 * time.Second)) {
+		t.Errorf("clock should be at %s, received %s", start.Add(90*time.Second), clock.Now())
+	}
+
+	select {
+	case fired := <-early.C():
+		if !fired.Equal(start.Add(time.Minute)) {
+			t.Errorf("timer should have fired at %s, received %s", start.Add(time.Minute), fired)
+		}
+	default:
+		t.Errorf("timer should have fired")
+	}
+
+	select {
+	case <-late.C():
+		t.Errorf("timer should not have fired")
+	case <-stopped.C():
+		t.Errorf("stopped timer should not have fired")
+	default:
+	}
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-late.C():
+	default:
+		t.Errorf("timer should have fired")
+	}
+
+	if clock.Timers() != 0 {
+		t.Errorf("clock should have no timers, received %d", clock.Timers())
+	}
+}
+
+func TestFakeClock_Reset(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	timer := clock.NewTimer(time.Minute)
+	if !timer.Reset(2 * time.Minute) {
+		t.Errorf("resetting an active timer should return true")
+	}
+
+	clock.Advance(time.Minute)
+	select {
+	case <-timer.C():
+		t.Errorf("timer should not have fired")
+	default:
+	}
+
+	clock.Advance(time.Minute)
+	select {
+	case <-timer.C():
+	default:
+		t.Errorf("timer should have fired")
+	}
+
+	if timer.Reset(0) {
+		t.Errorf("resetting an expired timer should return false")
+	}
+	select {
+	case <-timer.C():
+	default:
+		t.Errorf("timer reset to 0 should fire immediately")
+	}
+}
+
+func TestFakeClock_Sleep(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Hour)
+		close(done)
+	}()
+
+	clock.WaitForTimers(1)
+	select {
+	case <-done:
+		t.Fatalf("sleep should not return before the clock is advanced")
+	default:
+	}
+
+	clock.Advance(time.Hour)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("sleep should return after the clock is advanced")
+	}
+}
+
+func TestFakeClock_RemoveTimers(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	for range 100 {
+		done := make(chan struct{})
+		go func() {
+			clock.Sleep(time.Second)
+			close(done)
+		}()
+		clock.WaitForTimers(1)
+		clock.Advance(time.Second)
+		<-done
+	}
+
+	stopped := clock.NewTimer(time.Minute)
+	stopped.Stop()
+	reset := clock.NewTimer(time.Minute)
+	reset.Reset(2 * time.Minute)
+
+	clock.mux.Lock()
+	defer clock.mux.Unlock()
+	if len(clock.timers) != 1 || clock.timers[0] != reset {
+		t.Errorf("clock should only keep the timer waiting to fire, received %d timers", len(clock.timers))
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	clock := SystemClock()
+
+	timer := clock.NewTimer(time.Millisecond)
+	select {
+	case <-timer.C():
+	case <-time.After(time.Second):
+		t.Errorf("timer should have fired")
+	}
+
+	before := clock.Now()
+	clock.Sleep(time.Millisecond)
+	if clock.Now().Sub(before) < time.Millisecond {
+		t.Errorf("clock should have slept at least 1ms")
+	}
+}
@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	validHash = `^H(?:\((\d+)-(\d+)\))?(?:/(\d+))?$`
+
+	// hashMaxDay limits the day resolved by a plain H token, so the schedule is due in every month.
+	hashMaxDay = 28
+)
+
+var reHash = regexp.MustCompile(validHash)
+
+// resolveHash replaces the H tokens in the list items of expression with values derived from the seed.
+// The values are stable for the same seed and position, and differ between positions.
+//   - H resolves to a single value within the bounds of the position.
+//   - H(a-b) resolves to a single value between a and b.
+//   - H/n and H(a-b)/n resolve to every nth value, starting at an offset below n.
+//
+// Returns an error if an H token is invalid for the position.
+func resolveHash(expression string, p position, seed string) (string, error) {
+	if !strings.Contains(expression, "H") {
+		return expression, nil
+	}
+
+	items := strings.Split(expression, ",")
+	for i, item := range items {
+		if !strings.HasPrefix(item, "H") {
+			continue
+		}
+
+		m := reHash.FindStringSubmatch(item)
+		if m == nil {
+			return "", newParseError(p, ReasonUnknownLiteral, "invalid hash %s for %s", item, p.String())
+		}
+
+		low, high := p.min(), p.max()
+		if p == positionDay && m[1] == "" {
+			high = hashMaxDay
+		}
+		if m[1] != "" {
+			low, _ = strconv.Atoi(m[1])
+			high, _ = strconv.Atoi(m[2])
+			if low < p.min() || high > p.max() || low > high {
+				return "", newParseError(p, ReasonOutOfRange, "invalid hash range %s for %s, expected values between %d and %d", item, p.String(), p.min(), p.max())
+			}
+		}
+
+		h := hashOf(seed, p)
+		if m[3] == "" {
+			items[i] = strconv.Itoa(low + int(h%uint64(high-low+1)))
+			continue
+		}
+
+		step, _ := strconv.Atoi(m[3])
+		if step < 1 || step > high-low+1 {
+			return "", newParseError(p, ReasonBadStep, "invalid hash step %s for %s, expected a step between 1 and %d", item, p.String(), high-low+1)
+		}
+		items[i] = strconv.Itoa(low+int(h%uint64(step))) + "-" + strconv.Itoa(high) + "/" + m[3]
+	}
+	return strings.Join(items, ","), nil
+}
+
+// hashOf returns a stable hash of the seed for position p.
+func hashOf(seed string, p position) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(seed + "/" + p.String()))
+	return h.Sum64()
+}
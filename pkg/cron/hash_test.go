@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestResolveHash(t *testing.T) {
+	var tests = []struct {
+		expression string
+		p          position
+		success    bool
+	}{
+		{"*", positionMinute, true},
+		{"H", positionMinute, true},
+		{"H/15", positionMinute, true},
+		{"H(0-29)", positionMinute, true},
+		{"H(0-29)/10", positionMinute, true},
+		{"H,30", positionMinute, true},
+		{"H", positionDay, true},
+		{"H(1-5)", positionWeekday, true},
+
+		{"H(0-60)", positionMinute, false},
+		{"H(10-5)", positionMinute, false},
+		{"H(1-12)", positionHour, true},
+		{"H(0-12)", positionMonth, false},
+		{"H/0", positionMinute, false},
+		{"H/61", positionMinute, false},
+		{"H(0-9)/11", positionMinute, false},
+		{"HH", positionMinute, false},
+		{"H-5", positionMinute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+" "+tt.p.String(), func(t *testing.T) {
+			resolved, err := resolveHash(tt.expression, tt.p, "job")
+			if (err == nil) != tt.success {
+				t.Fatalf("unexpected result for %s with error: %v", tt.expression, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if _, err = newElement(resolved, tt.p); err != nil {
+				t.Errorf("resolved %s to invalid element %s: %v", tt.expression, resolved, err)
+			}
+		})
+	}
+}
+
+func TestResolveHash_Bounds(t *testing.T) {
+	var tests = []struct {
+		expression string
+		p          position
+		low        int
+		high       int
+	}{
+		{"H", positionMinute, 0, 59},
+		{"H", positionDay, 1, hashMaxDay},
+		{"H(10-20)", positionHour, 10, 20},
+		{"H", positionWeekday, 0, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+" "+tt.p.String(), func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				resolved, err := resolveHash(tt.expression, tt.p, strconv.Itoa(i))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if v, _ := strconv.Atoi(resolved); v < tt.low || v > tt.high {
+					t.Fatalf("resolved %s to %s, expected a value between %d and %d", tt.expression, resolved, tt.low, tt.high)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveHash_Spread(t *testing.T) {
+	offsets := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		resolved, _ := resolveHash("H/5", positionMinute, strconv.Itoa(i))
+		offsets[resolved]++
+	}
+
+	if len(offsets) != 5 {
+		t.Fatalf("got %d different offsets, expected 5: %v", len(offsets), offsets)
+	}
+	for resolved, count := range offsets {
+		if count < 100 {
+			t.Errorf("offset %s only used %d times out of 1000", resolved, count)
+		}
+	}
+}
+
+func TestSchedule_Seed(t *testing.T) {
+	a, _ := NewSchedule("H H(0-5) * * *", WithSeed("a"))
+	b, _ := NewSchedule("H H(0-5) * * *", WithSeed("a"))
+	c, _ := NewSchedule("H H(0-5) * * *", WithSeed("c"))
+
+	if a.String() != "H H(0-5) * * *" {
+		t.Errorf("got %s, expected the expression with H tokens", a.String())
+	}
+
+	for i := range a.elements {
+		if a.elements[i].expression != b.elements[i].expression {
+			t.Errorf("schedules with the same seed resolved %s to %s and %s", a.elements[i].p, a.elements[i].expression, b.elements[i].expression)
+		}
+	}
+
+	if a.elements[positionMinute].expression == c.elements[positionMinute].expression && a.elements[positionHour].expression == c.elements[positionHour].expression {
+		t.Errorf("schedules with different seeds resolved to the same time")
+	}
+}
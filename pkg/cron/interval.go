@@ -0,0 +1,79 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// NewInterval returns an Interval which is due every d, starting at anchor.
+// If anchor is the zero time, the interval is anchored to the Unix epoch, so it is due at the same times across restarts.
+// Returns an error if d is shorter than a second, or is not a whole number of seconds.
+func NewInterval(d time.Duration, anchor time.Time) (Interval, error) {
+	if d < time.Second || d%time.Second != 0 {
+		return Interval{}, fmt.Errorf("invalid interval %s, expected a whole number of seconds", d)
+	}
+
+	if anchor.IsZero() {
+		anchor = time.Unix(0, 0).UTC()
+	}
+
+	return Interval{
+		every:  d,
+		anchor: anchor,
+	}, nil
+}
+
+// Interval defines a timetable which is due at a fixed interval from an anchor time.
+// Interval should always be created using NewInterval for proper initialization.
+type Interval struct {
+	every  time.Duration
+	anchor time.Time
+}
+
+// Anchor returns the first time at which the interval is due.
+func (i Interval) Anchor() time.Time {
+	return i.anchor
+}
+
+// Every returns the duration between two times at which the interval is due.
+func (i Interval) Every() time.Duration {
+	return i.every
+}
+
+// IsDue checks if t falls within the second the interval is due.
+func (i Interval) IsDue(t time.Time) bool {
+	if i.every == 0 || t.Before(i.anchor) {
+		return false
+	}
+	return t.Sub(i.anchor)%i.every < time.Second
+}
+
+// Next returns the first time after the input time at which the interval is due.
+func (i Interval) Next(after time.Time) (time.Time, bool) {
+	if i.every == 0 {
+		return time.Time{}, false
+	}
+
+	if after.Before(i.anchor) {
+		return i.anchor, true
+	}
+	return i.anchor.Add((after.Sub(i.anchor)/i.every + 1) * i.every), true
+}
+
+// Prev returns the last time before the input time at which the interval was due.
+// Returns false if the input time is not after the anchor.
+func (i Interval) Prev(before time.Time) (time.Time, bool) {
+	if i.every == 0 || !before.After(i.anchor) {
+		return time.Time{}, false
+	}
+	return i.anchor.Add((before.Sub(i.anchor) - 1) / i.every * i.every), true
+}
+
+// String returns the interval as an @every expression.
+// The anchor is only included when the interval is not anchored to the Unix epoch.
+func (i Interval) String() string {
+	if i.anchor.Equal(time.Unix(0, 0)) {
+		return everyPrefix + i.every.String()
+	}
+	return everyPrefix + i.every.String() + " from " + i.anchor.Format(time.RFC3339)
+}
@@ -0,0 +1,128 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewInterval(t *testing.T) {
+	var tests = []struct {
+		every   time.Duration
+		success bool
+	}{
+		{0, false},
+		{-time.Second, false},
+		{500 * time.Millisecond, false},
+		{1500 * time.Millisecond, false},
+
+		{time.Second, true},
+		{90 * time.Second, true},
+		{time.Hour + 30*time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.every.String(), func(t *testing.T) {
+			_, err := NewInterval(tt.every, time.Time{})
+			if (err == nil) != tt.success {
+				t.Errorf("unexpected result for %s with error: %v", tt.every, err)
+			}
+		})
+	}
+}
+
+func TestInterval_IsDue(t *testing.T) {
+	anchor := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	i, _ := NewInterval(90*time.Second, anchor)
+
+	var tests = []struct {
+		time   time.Time
+		wanted bool
+	}{
+		{anchor, true},
+		{anchor.Add(500 * time.Millisecond), true},
+		{anchor.Add(time.Second), false},
+		{anchor.Add(-90 * time.Second), false},
+		{anchor.Add(90 * time.Second), true},
+		{anchor.Add(180 * time.Second), true},
+		{anchor.Add(200 * time.Second), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.time.String(), func(t *testing.T) {
+			if got := i.IsDue(tt.time); got != tt.wanted {
+				t.Errorf("IsDue(%s) = %t, wanted %t", tt.time, got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestInterval_Next(t *testing.T) {
+	anchor := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	i, _ := NewInterval(90*time.Second, anchor)
+
+	var tests = []struct {
+		after  time.Time
+		wanted time.Time
+	}{
+		{anchor.Add(-time.Hour), anchor},
+		{anchor.Add(-time.Second), anchor},
+		{anchor, anchor.Add(90 * time.Second)},
+		{anchor.Add(89 * time.Second), anchor.Add(90 * time.Second)},
+		{anchor.Add(90 * time.Second), anchor.Add(180 * time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.after.String(), func(t *testing.T) {
+			got, ok := i.Next(tt.after)
+			if !ok || !got.Equal(tt.wanted) {
+				t.Errorf("Next(%s) = %s, %t, wanted %s", tt.after, got, ok, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestInterval_Prev(t *testing.T) {
+	anchor := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	i, _ := NewInterval(90*time.Second, anchor)
+
+	var tests = []struct {
+		before time.Time
+		wanted time.Time
+		ok     bool
+	}{
+		{anchor.Add(-time.Hour), time.Time{}, false},
+		{anchor, time.Time{}, false},
+		{anchor.Add(time.Second), anchor, true},
+		{anchor.Add(90 * time.Second), anchor, true},
+		{anchor.Add(91 * time.Second), anchor.Add(90 * time.Second), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.before.String(), func(t *testing.T) {
+			got, ok := i.Prev(tt.before)
+			if ok != tt.ok || !got.Equal(tt.wanted) {
+				t.Errorf("Prev(%s) = %s, %t, wanted %s, %t", tt.before, got, ok, tt.wanted, tt.ok)
+			}
+		})
+	}
+}
+
+func TestInterval_String(t *testing.T) {
+	var tests = []struct {
+		every  time.Duration
+		anchor time.Time
+		wanted string
+	}{
+		{90 * time.Second, time.Time{}, "@every 1m30s"},
+		{time.Hour, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), "@every 1h0m0s from 2025-01-01T12:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wanted, func(t *testing.T) {
+			i, _ := NewInterval(tt.every, tt.anchor)
+			if got := i.String(); got != tt.wanted {
+				t.Errorf("String() = %s, wanted %s", got, tt.wanted)
+			}
+		})
+	}
+}
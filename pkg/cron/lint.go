@@ -0,0 +1,122 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Diagnostic describes a problem found in an expression by Lint.
+type Diagnostic struct {
+	Severity Severity
+	Position string // name of the element, such as day or weekday, or empty if the diagnostic applies to the whole expression
+	Offset   int    // offset of the first character of the element in the expression
+	Message  string
+}
+
+// String returns the diagnostic as a single line.
+func (d Diagnostic) String() string {
+	if d.Position == "" {
+		return fmt.Sprintf("%s at offset %d: %s", d.Severity.String(), d.Offset, d.Message)
+	}
+	return fmt.Sprintf("%s in %s at offset %d: %s", d.Severity.String(), d.Position, d.Offset, d.Message)
+}
+
+// Lint checks expression for errors and for elements which probably do not do what was intended.
+// Errors are reported when the expression cannot be parsed using NewSchedule and the options.
+// Warnings are reported when the schedule will never be due after now, when a step does not repeat evenly,
+// or when the schedule is due on either the day or the weekday because both of them are restricted.
+// Returns no diagnostics if no problems are found.
+func Lint(expression string, now time.Time, opts ...ScheduleOption) []Diagnostic {
+	s, err := NewSchedule(expression, opts...)
+	if err != nil {
+		return []Diagnostic{lintError(err)}
+	}
+
+	elements, _ := fields(expression)
+
+	var diagnostics []Diagnostic
+	warn := func(p position, format string, a ...any) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: SeverityWarning,
+			Position: p.String(),
+			Offset:   elements[p].offset,
+			Message:  fmt.Sprintf(format, a...),
+		})
+	}
+
+	for _, p := range []position{positionSecond, positionMinute, positionHour, positionMonth, positionWeekday} {
+		e := s.element(p)
+		if !strings.HasPrefix(e.expression, "*/") {
+			continue
+		}
+
+		terms, _ := e.parseExpression()
+		if count := p.max() - p.min() + 1; count%terms[0].step != 0 {
+			last := p.min() + (count-1)/terms[0].step*terms[0].step
+			warn(p, "step %d does not divide %d values evenly, %d is followed by %d", terms[0].step, count, last, p.min())
+		}
+	}
+
+	day, weekday := s.element(positionDay), s.element(positionWeekday)
+	orDays := !s.strictDays && day.restricted() && weekday.restricted()
+	if orDays {
+		warn(positionWeekday, "day and weekday are both restricted, the schedule is due when either of them matches")
+	}
+
+	var never bool
+	if !orDays && !weekday.restricted() {
+		if months := s.missingDayMonths(); len(months) > 0 {
+			warn(positionDay, "day %s never occurs in %s", day.expression, strings.Join(months, ", "))
+			never = true
+		}
+	}
+
+	if _, ok := s.Next(now); !ok && !never {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: SeverityWarning,
+			Message:  "schedule is never due",
+		})
+	}
+	return diagnostics
+}
+
+// lintError returns the diagnostic for an error returned by NewSchedule.
+func lintError(err error) Diagnostic {
+	d := Diagnostic{
+		Severity: SeverityError,
+		Message:  err.Error(),
+	}
+
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		d.Position = pe.Position
+		d.Offset = pe.Start
+	}
+	return d
+}
+
+// missingDayMonths returns the months of the schedule in which none of the days of the schedule occur.
+// Returns nil if the days occur in any of the months, or if the day depends on the calendar.
+func (s *Schedule) missingDayMonths() []string {
+	day, month := s.element(positionDay), s.element(positionMonth)
+	if day.wildcard() || day.isCalendar() {
+		return nil
+	}
+
+	var missing []string
+	for m := 1; m <= 12; m++ {
+		if !month.match(m) {
+			continue
+		}
+
+		for d := 1; d <= daysIn(2000, time.Month(m)); d++ { // 2000 is a leap year, so February has 29 days
+			if day.match(d) {
+				return nil
+			}
+		}
+		missing = append(missing, LocaleEnglish.Months[m-1])
+	}
+	return missing
+}
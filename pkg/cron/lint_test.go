@@ -0,0 +1,49 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLint(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		expression string
+		wanted     []Diagnostic
+	}{
+		{"0 30 2 * 3 1-5", nil},
+		{"*/15 * * * *", nil},
+		{"@daily", nil},
+		{"CRON_TZ=Europe/Brussels 0 2 * * *", nil},
+
+		{"0 0 31 2 *", []Diagnostic{{SeverityWarning, "day", 4, "day 31 never occurs in February"}}},
+		{"0 0 30,31 2 *", []Diagnostic{{SeverityWarning, "day", 4, "day 30,31 never occurs in February"}}},
+		{"0 0 1 * MON", []Diagnostic{{SeverityWarning, "weekday", 8, "day and weekday are both restricted, the schedule is due when either of them matches"}}},
+		{"*/7 * * * *", []Diagnostic{{SeverityWarning, "minute", 0, "step 7 does not divide 60 values evenly, 56 is followed by 0"}}},
+		{"0 0 0 1 1 * 1999", []Diagnostic{{SeverityWarning, "", 0, "schedule is never due"}}},
+		{"0 0 0 1 1 * 2026", []Diagnostic{{SeverityWarning, "", 0, "schedule is never due"}}},
+		{"0 0 0 1 1 * 2027", nil},
+
+		{"0 0 32 * *", []Diagnostic{{SeverityError, "day", 4, "value out of range in day"}}},
+		{"TZ=UTC  0 0 * FOO *", []Diagnostic{{SeverityError, "month", 14, "invalid expression in month"}}},
+		{"0 0 * * MON-FRI/0", []Diagnostic{{SeverityError, "weekday", 8, "invalid step 0 in weekday"}}},
+		{"0 0 * *", []Diagnostic{{SeverityError, "", 0, "invalid element count, got 4, expected 5-7 elements separated by space"}}},
+		{"CRON_TZ=Nowhere/Atlantis 0 0 * * *", []Diagnostic{{SeverityError, "", 0, "invalid location Nowhere/Atlantis: unknown time zone Nowhere/Atlantis"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			got := Lint(tt.expression, now)
+			if len(got) != len(tt.wanted) {
+				t.Fatalf("got %d diagnostics %v, wanted %v", len(got), got, tt.wanted)
+			}
+
+			for i := range got {
+				if got[i] != tt.wanted[i] {
+					t.Errorf("got %s, wanted %s", got[i].String(), tt.wanted[i].String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package cron
+
+// Locale contains the words and phrases used by DescribeIn to describe a schedule in a language.
+// The phrases are format strings. Arrays of phrases are indexed by position: second, minute, hour, day, month, weekday and year.
+type Locale struct {
+	Months   [12]string // January to December
+	Weekdays [7]string  // Sunday to Saturday
+	Ordinals [5]string  // first to fifth
+
+	At            string    // time of day, such as 02:30
+	Every         [7]string // no arguments
+	EveryN        [7]string // step
+	EveryNBetween [7]string // step, first value and last value
+	Value         [7]string // single value
+	Values        [7]string // list of values
+	Range         string    // first value and last value
+	RangeStep     string    // first value, last value and step
+
+	EveryWeekday       string // no arguments, used for Monday to Friday
+	LastDay            string // no arguments
+	LastWeekdayOfMonth string // no arguments
+	NearestWeekday     string // day
+	NthWeekday         string // ordinal and weekday
+	LastWeekday        string // weekday
+
+	Separator     string // between the parts of the description
+	ListSeparator string // between the values in a list
+	ListLast      string // before the last value in a list
+	And           string // between day and weekday, when both of them must match
+	Or            string // between day and weekday, when either of them must match
+}
+
+var (
+	LocaleEnglish = Locale{
+		Months:   [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		Weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		Ordinals: [5]string{"first", "second", "third", "fourth", "fifth"},
+
+		At:            "at %s",
+		Every:         [7]string{"every second", "every minute", "every hour", "every day", "every month", "every day", "every year"},
+		EveryN:        [7]string{"every %d seconds", "every %d minutes", "every %d hours", "every %d days", "every %d months", "every %d days of the week", "every %d years"},
+		EveryNBetween: [7]string{"every %d seconds from %s through %s", "every %d minutes from %s through %s", "every %d hours from %s through %s", "every %d days from day %s through %s of the month", "every %d months from %s through %s", "every %d days from %s through %s", "every %d years from %s through %s"},
+		Value:         [7]string{"at second %s", "at minute %s", "at hour %s", "on day %s of the month", "in %s", "on %s", "in %s"},
+		Values:        [7]string{"at seconds %s", "at minutes %s", "at hours %s", "on days %s of the month", "in %s", "on %s", "in %s"},
+		Range:         "%s through %s",
+		RangeStep:     "every %[3]d from %[1]s through %[2]s",
+
+		EveryWeekday:       "on every weekday",
+		LastDay:            "on the last day of the month",
+		LastWeekdayOfMonth: "on the last weekday of the month",
+		NearestWeekday:     "on the weekday nearest day %d of the month",
+		NthWeekday:         "on the %s %s of the month",
+		LastWeekday:        "on the last %s of the month",
+
+		Separator:     " ",
+		ListSeparator: ", ",
+		ListLast:      " and ",
+		And:           " and ",
+		Or:            " or ",
+	}
+
+	LocaleDutch = Locale{
+		Months:   [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		Weekdays: [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		Ordinals: [5]string{"eerste", "tweede", "derde", "vierde", "vijfde"},
+
+		At:            "om %s",
+		Every:         [7]string{"elke seconde", "elke minuut", "elk uur", "elke dag", "elke maand", "elke dag", "elk jaar"},
+		EveryN:        [7]string{"elke %d seconden", "elke %d minuten", "elke %d uur", "elke %d dagen", "elke %d maanden", "elke %d dagen van de week", "elke %d jaar"},
+		EveryNBetween: [7]string{"elke %d seconden van %s tot en met %s", "elke %d minuten van %s tot en met %s", "elke %d uur van %s tot en met %s", "elke %d dagen van dag %s tot en met %s van de maand", "elke %d maanden van %s tot en met %s", "elke %d dagen van %s tot en met %s", "elke %d jaar van %s tot en met %s"},
+		Value:         [7]string{"op seconde %s", "op minuut %s", "om uur %s", "op dag %s van de maand", "in %s", "op %s", "in %s"},
+		Values:        [7]string{"op seconden %s", "op minuten %s", "om uren %s", "op dagen %s van de maand", "in %s", "op %s", "in %s"},
+		Range:         "%s tot en met %s",
+		RangeStep:     "elke %[3]d van %[1]s tot en met %[2]s",
+
+		EveryWeekday:       "op elke weekdag",
+		LastDay:            "op de laatste dag van de maand",
+		LastWeekdayOfMonth: "op de laatste weekdag van de maand",
+		NearestWeekday:     "op de weekdag het dichtst bij dag %d van de maand",
+		NthWeekday:         "op de %s %s van de maand",
+		LastWeekday:        "op de laatste %s van de maand",
+
+		Separator:     " ",
+		ListSeparator: ", ",
+		ListLast:      " en ",
+		And:           " en ",
+		Or:            " of ",
+	}
+)
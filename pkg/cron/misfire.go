@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"slices"
+	"time"
+)
+
+// misfireThreshold is how late a time can be fired before it is considered missed.
+const misfireThreshold = 1 * time.Second
+
+// IgnoreMisfires returns a MisfirePolicy which drops all missed times.
+func IgnoreMisfires() MisfirePolicy {
+	return MisfirePolicy{
+		Mode: MisfireIgnore,
+	}
+}
+
+// FireOnceNow returns a MisfirePolicy which fires the last missed time once.
+// Missed times older than grace are dropped, a grace of 0 keeps all missed times.
+func FireOnceNow(grace time.Duration) MisfirePolicy {
+	return MisfirePolicy{
+		Mode:    MisfireFireOnceNow,
+		MaxRuns: 1,
+		Grace:   grace,
+	}
+}
+
+// FireAll returns a MisfirePolicy which fires the last maxRuns missed times, in the order they were due.
+// Missed times older than grace are dropped, a grace of 0 keeps all missed times.
+func FireAll(maxRuns int, grace time.Duration) MisfirePolicy {
+	return MisfirePolicy{
+		Mode:    MisfireFireAll,
+		MaxRuns: max(maxRuns, 1),
+		Grace:   grace,
+	}
+}
+
+// MisfirePolicy defines what happens with the times at which a timetable was due, but which were not fired in time.
+// Times are missed when the process was not running, or when the ticker was not able to fire them within a second.
+// The zero value ignores all missed times.
+type MisfirePolicy struct {
+	Mode    MisfireMode
+	MaxRuns int           // maximum number of missed times to fire
+	Grace   time.Duration // maximum age of a missed time, 0 means no limit
+}
+
+// Missed returns the times at which tt was due after last and at or before now, which must be fired according to the policy.
+// The times are returned in the order they were due.
+func (p MisfirePolicy) Missed(tt Timetable, last time.Time, now time.Time) []time.Time {
+	if p.Mode == MisfireIgnore || p.MaxRuns < 1 {
+		return nil
+	}
+
+	// Walk back from now, so only the most recent times are evaluated.
+	var times []time.Time
+	for cursor := now.Add(time.Nanosecond); len(times) < p.MaxRuns; {
+		prev, ok := tt.Prev(cursor)
+		if !ok || !prev.After(last) || (p.Grace > 0 && now.Sub(prev) > p.Grace) {
+			break
+		}
+		times = append(times, prev)
+		cursor = prev
+	}
+
+	slices.Reverse(times)
+	return times
+}
@@ -0,0 +1,36 @@
+package cron
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	MisfireIgnore      MisfireMode = iota // missed times are dropped
+	MisfireFireOnceNow                    // the last missed time is fired once
+	MisfireFireAll                        // the missed times are fired, up to a maximum number of runs
+)
+
+var misfireModeStrings = []string{"ignore", "fire-once-now", "fire-all"}
+
+type MisfireMode int
+
+func (m MisfireMode) String() string {
+	return misfireModeStrings[m]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m MisfireMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *MisfireMode) UnmarshalText(text []byte) error {
+	i := slices.Index(misfireModeStrings, strings.ToLower(string(text)))
+	if i < 0 {
+		return fmt.Errorf("invalid misfire mode %s, expected one of %s", text, strings.Join(misfireModeStrings, ", "))
+	}
+	*m = MisfireMode(i)
+	return nil
+}
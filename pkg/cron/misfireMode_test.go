@@ -0,0 +1,39 @@
+package cron
+
+import "testing"
+
+func TestMisfireMode_String(t *testing.T) {
+	var (
+		result []string
+		wanted = misfireModeStrings
+	)
+
+	for i := 0; i < len(wanted); i++ {
+		result = append(result, MisfireMode(i).String())
+	}
+
+	for j := 0; j < len(wanted); j++ {
+		if result[j] != wanted[j] {
+			t.Errorf("invalid string: got %s expected %s", result[j], wanted[j])
+		}
+	}
+}
+
+func TestMisfireMode_UnmarshalText(t *testing.T) {
+	for i, s := range misfireModeStrings {
+		var m MisfireMode
+		if err := m.UnmarshalText([]byte(s)); err != nil || m != MisfireMode(i) {
+			t.Errorf("%s should unmarshal to %d, received %d (%v)", s, i, m, err)
+		}
+
+		text, _ := m.MarshalText()
+		if string(text) != s {
+			t.Errorf("%d should marshal to %s, received %s", i, s, text)
+		}
+	}
+
+	var m MisfireMode
+	if err := m.UnmarshalText([]byte("fire-twice")); err == nil {
+		t.Errorf("unknown misfire mode should return an error")
+	}
+}
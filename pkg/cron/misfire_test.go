@@ -0,0 +1,43 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMisfirePolicy_Missed(t *testing.T) {
+	s, _ := NewSchedule("0 * * * *")
+	now := time.Date(2025, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name   string
+		policy MisfirePolicy
+		last   time.Time
+		wanted []string
+	}{
+		{"ignore", IgnoreMisfires(), now.Add(-3 * time.Hour), nil},
+		{"zero value", MisfirePolicy{}, now.Add(-3 * time.Hour), nil},
+		{"fire once now", FireOnceNow(0), now.Add(-3 * time.Hour), []string{"12:00"}},
+		{"fire once now without missed times", FireOnceNow(0), now.Add(-10 * time.Minute), nil},
+		{"fire once now outside grace", FireOnceNow(20 * time.Minute), now.Add(-3 * time.Hour), nil},
+		{"fire all", FireAll(5, 0), now.Add(-3 * time.Hour), []string{"10:00", "11:00", "12:00"}},
+		{"fire all after last", FireAll(5, 0), time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), []string{"11:00", "12:00"}},
+		{"fire all limited by runs", FireAll(2, 0), now.Add(-3 * time.Hour), []string{"11:00", "12:00"}},
+		{"fire all limited by grace", FireAll(5, 90*time.Minute), now.Add(-3 * time.Hour), []string{"11:00", "12:00"}},
+		{"fire all at least one run", FireAll(0, 0), now.Add(-3 * time.Hour), []string{"12:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Missed(s, tt.last, now)
+			if len(got) != len(tt.wanted) {
+				t.Fatalf("got %v, wanted %v", got, tt.wanted)
+			}
+			for i := range got {
+				if got[i].Format("15:04") != tt.wanted[i] {
+					t.Errorf("got %s, wanted %s", got[i].Format("15:04"), tt.wanted[i])
+				}
+			}
+		})
+	}
+}
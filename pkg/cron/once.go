@@ -0,0 +1,46 @@
+package cron
+
+import "time"
+
+// NewOnce returns a Once which is due at t.
+func NewOnce(t time.Time) Once {
+	return Once{
+		at: t,
+	}
+}
+
+// Once defines a timetable which is due a single time.
+type Once struct {
+	at time.Time
+}
+
+// At returns the time at which the timetable is due.
+func (o Once) At() time.Time {
+	return o.at
+}
+
+// IsDue checks if t falls within the second the timetable is due.
+func (o Once) IsDue(t time.Time) bool {
+	return !t.Before(o.at) && t.Before(o.at.Add(time.Second))
+}
+
+// Next returns the time at which the timetable is due, if it is after the input time.
+func (o Once) Next(after time.Time) (time.Time, bool) {
+	if !o.at.After(after) {
+		return time.Time{}, false
+	}
+	return o.at, true
+}
+
+// Prev returns the time at which the timetable is due, if it is before the input time.
+func (o Once) Prev(before time.Time) (time.Time, bool) {
+	if !o.at.Before(before) {
+		return time.Time{}, false
+	}
+	return o.at, true
+}
+
+// String returns the timetable as an @at expression.
+func (o Once) String() string {
+	return atPrefix + o.at.Format(time.RFC3339)
+}
@@ -0,0 +1,48 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnce_IsDue(t *testing.T) {
+	at := time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC)
+	o := NewOnce(at)
+
+	var tests = []struct {
+		time   time.Time
+		wanted bool
+	}{
+		{at, true},
+		{at.Add(999 * time.Millisecond), true},
+		{at.Add(-time.Millisecond), false},
+		{at.Add(time.Second), false},
+		{at.AddDate(1, 0, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.time.String(), func(t *testing.T) {
+			if got := o.IsDue(tt.time); got != tt.wanted {
+				t.Errorf("IsDue(%s) = %t, wanted %t", tt.time, got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestOnce_NextPrev(t *testing.T) {
+	at := time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC)
+	o := NewOnce(at)
+
+	if got, ok := o.Next(at.Add(-time.Second)); !ok || !got.Equal(at) {
+		t.Errorf("Next() = %s, %t, wanted %s", got, ok, at)
+	}
+	if _, ok := o.Next(at); ok {
+		t.Errorf("Next() at the time itself should not be due")
+	}
+	if got, ok := o.Prev(at.Add(time.Second)); !ok || !got.Equal(at) {
+		t.Errorf("Prev() = %s, %t, wanted %s", got, ok, at)
+	}
+	if _, ok := o.Prev(at); ok {
+		t.Errorf("Prev() at the time itself should not be due")
+	}
+}
@@ -0,0 +1,40 @@
+package cron
+
+import "fmt"
+
+// ParseError is returned by NewSchedule when an expression cannot be parsed.
+// Use errors.As to retrieve it from the error.
+type ParseError struct {
+	Position string           // name of the element, such as day or weekday, or empty if the error applies to the whole expression
+	Token    string           // element or prefix as it was written in the expression
+	Start    int              // offset of the first character of the token in the expression
+	End      int              // offset after the last character of the token in the expression
+	Reason   ParseErrorReason // code for the kind of error
+	Message  string
+}
+
+// Error returns the message of the error.
+func (e *ParseError) Error() string {
+	return e.Message
+}
+
+// newParseError returns a ParseError for the element at position p with the input reason and message.
+// The token and span are set by NewSchedule, which knows where the element is located in the expression.
+func newParseError(p position, reason ParseErrorReason, format string, a ...any) *ParseError {
+	return &ParseError{
+		Position: p.String(),
+		Reason:   reason,
+		Message:  fmt.Sprintf(format, a...),
+	}
+}
+
+// newExpressionError returns a ParseError for the whole expression with the input reason and message.
+func newExpressionError(token string, start int, reason ParseErrorReason, format string, a ...any) *ParseError {
+	return &ParseError{
+		Token:   token,
+		Start:   start,
+		End:     start + len(token),
+		Reason:  reason,
+		Message: fmt.Sprintf(format, a...),
+	}
+}
@@ -0,0 +1,19 @@
+package cron
+
+const (
+	ReasonOutOfRange      ParseErrorReason = iota // a value is outside the bounds of its position
+	ReasonBadStep                                 // a step is lower than 1 or larger than the values of its position
+	ReasonUnorderedList                           // values in a list or a range are not in ascending order
+	ReasonUnknownLiteral                          // a token is not valid for its position
+	ReasonWrongFieldCount                         // the expression does not have 5 to 7 elements
+	ReasonInvalidLocation                         // the location in the CRON_TZ= or TZ= prefix cannot be loaded
+	ReasonUnknownCalendar                         // the calendar in the CAL= prefix is not registered or has an unknown policy
+)
+
+var parseErrorReasonStrings = []string{"out-of-range", "bad-step", "unordered-list", "unknown-literal", "wrong-field-count", "invalid-location", "unknown-calendar"}
+
+type ParseErrorReason int
+
+func (r ParseErrorReason) String() string {
+	return parseErrorReasonStrings[r]
+}
@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNewSchedule_ParseError(t *testing.T) {
+	_ = RegisterCalendar(NewCalendar("business"))
+
+	var tests = []struct {
+		expression string
+		wanted     ParseError
+	}{
+		{"0 0 32 * *", ParseError{Position: "day", Token: "32", Start: 4, End: 6, Reason: ReasonOutOfRange}},
+		{"0 0 * * 1#6", ParseError{Position: "weekday", Token: "1#6", Start: 8, End: 11, Reason: ReasonOutOfRange}},
+		{"0 0 * * * 10000", ParseError{Position: "year", Token: "10000", Start: 10, End: 15, Reason: ReasonOutOfRange}},
+		{"H(0-60) * * * *", ParseError{Position: "minute", Token: "H(0-60)", Start: 0, End: 7, Reason: ReasonOutOfRange}},
+		{"*/0 * * * *", ParseError{Position: "minute", Token: "*/0", Start: 0, End: 3, Reason: ReasonBadStep}},
+		{"0 */61 * * * *", ParseError{Position: "minute", Token: "*/61", Start: 2, End: 6, Reason: ReasonBadStep}},
+		{"0 10,5 * * *", ParseError{Position: "hour", Token: "10,5", Start: 2, End: 6, Reason: ReasonUnorderedList}},
+		{"0 0 * * FRI-MON", ParseError{Position: "weekday", Token: "FRI-MON", Start: 8, End: 15, Reason: ReasonUnorderedList}},
+		{"0 0 * foo *", ParseError{Position: "month", Token: "foo", Start: 6, End: 9, Reason: ReasonUnknownLiteral}},
+		{"?  0 * * *", ParseError{Position: "minute", Token: "?", Start: 0, End: 1, Reason: ReasonUnknownLiteral}},
+		{" 0 0 * *", ParseError{Token: "0 0 * *", Start: 1, End: 8, Reason: ReasonWrongFieldCount}},
+		{"TZ=UTC 0 0 * * * * * *", ParseError{Token: "0 0 * * * * * *", Start: 7, End: 22, Reason: ReasonWrongFieldCount}},
+		{"CRON_TZ=Nowhere/Atlantis 0 0 * * *", ParseError{Token: "CRON_TZ=Nowhere/Atlantis", Start: 0, End: 24, Reason: ReasonInvalidLocation}},
+		{"TZ=UTC CAL=holidays 0 0 * * *", ParseError{Token: "CAL=holidays", Start: 7, End: 19, Reason: ReasonUnknownCalendar}},
+		{"CAL=business:later 0 0 * * *", ParseError{Token: "CAL=business:later", Start: 0, End: 18, Reason: ReasonUnknownCalendar}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := NewSchedule(tt.expression)
+
+			var pe *ParseError
+			if !errors.As(fmt.Errorf("wrapped: %w", err), &pe) {
+				t.Fatalf("expected a ParseError, got %v", err)
+			}
+
+			got := *pe
+			got.Message = ""
+			if got != tt.wanted {
+				t.Errorf("got %+v, wanted %+v", got, tt.wanted)
+			}
+			if pe.Error() == "" {
+				t.Errorf("expected an error message")
+			}
+		})
+	}
+}
@@ -10,10 +10,40 @@ const (
 	positionYear
 )
 
-var positionStrings = []string{"second", "minute", "hour", "day", "month", "weekday", "year"}
+var (
+	positionStrings = []string{"second", "minute", "hour", "day", "month", "weekday", "year"}
+	positionBounds  = [][2]int{{0, 59}, {0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}, {0, 9999}}
+)
 
 type position int
 
 func (p position) String() string {
 	return positionStrings[p]
 }
+
+// min returns the lowest value allowed for the position.
+func (p position) min() int {
+	return positionBounds[p][0]
+}
+
+// max returns the highest value allowed for the position.
+func (p position) max() int {
+	return positionBounds[p][1]
+}
+
+// limit returns the highest value accepted in an expression for the position.
+// The weekday accepts 7 as well, as Sunday.
+func (p position) limit() int {
+	if p == positionWeekday {
+		return 7
+	}
+	return p.max()
+}
+
+// normalize returns value v as a value between the bounds of the position, which changes weekday 7 to Sunday.
+func (p position) normalize(v int) int {
+	if p == positionWeekday && v == 7 {
+		return 0
+	}
+	return v
+}
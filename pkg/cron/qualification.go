@@ -1,17 +1,19 @@
 package cron
 
 const (
-	qualificationNone   qualification = iota
-	qualificationSimple               // qualification does not contain any of the qualification characters
-	qualificationMulti                // qualification contains a comma
-	qualificationRange                // qualification contains a dash
-	qualificationStep                 // qualification contains a slash
+	qualificationNone           qualification = iota
+	qualificationSimple                       // qualification does not contain any of the qualification characters
+	qualificationMulti                        // qualification contains a comma, each value can be a single value, range or step
+	qualificationRange                        // qualification contains a dash
+	qualificationStep                         // qualification contains a slash, optionally applied to a range
+	qualificationAny                          // qualification is a question mark, only for day and weekday
+	qualificationLastDay                      // qualification is L, only for day
+	qualificationNearestWeekday               // qualification ends with W, only for day
+	qualificationNthWeekday                   // qualification contains a hash, only for weekday
+	qualificationLastWeekday                  // qualification ends with L, only for weekday
 )
 
-var (
-	qualificationStrings = []string{"none", "simple", "multi", "range", "step"}
-	qualificationChars   = []string{",", "-", "/"}
-)
+var qualificationStrings = []string{"none", "simple", "multi", "range", "step", "any", "last-day", "nearest-weekday", "nth-weekday", "last-weekday"}
 
 type qualification int
 
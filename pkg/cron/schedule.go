@@ -1,18 +1,34 @@
 package cron
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
+	"unicode"
 )
 
 const (
-	validSpace = `\s+`
+	validSpace    = `\s+`
+	validLocation = `^(?:CRON_)?TZ=(\S+)\s+`
+	validCalendar = `^CAL=([^\s:]+)(?::(\S+))?\s+`
+	validDays     = `^DAYS=(\S+)\s+`
+	validPrefix   = `^(?:(?:CRON_)?TZ|CAL|DAYS)=`
+	validToken    = `\S+`
+
+	// searchYears limits how many years Next and Prev will look ahead or back for a matching time.
+	searchYears = 100
 )
 
 var (
-	reSpace = regexp.MustCompile(validSpace)
+	reSpace    = regexp.MustCompile(validSpace)
+	reLocation = regexp.MustCompile(validLocation)
+	reCalendar = regexp.MustCompile(validCalendar)
+	reDays     = regexp.MustCompile(validDays)
+	rePrefix   = regexp.MustCompile(validPrefix)
+	reToken    = regexp.MustCompile(validToken)
 
 	cronWeekdayLiterals = strings.NewReplacer(
 		"SUN", "0",
@@ -96,57 +112,613 @@ func EverySecond() Schedule {
 }
 
 // NewSchedule returns a Schedule based on the input expression.
-// Returns an error if the expression cannot be parsed into separate elements.
-func NewSchedule(expression string) (Schedule, error) {
+// The expression can be prefixed with CRON_TZ=<location> or TZ=<location> to evaluate the schedule in that location.
+// After the location, the expression can be prefixed with CAL=<name>:<policy> to apply a registered calendar to the schedule.
+// The policy is one of skip, next or previous and defaults to skip.
+// After the calendar, the expression can be prefixed with DAYS=strict to use strict day matching, as WithStrictDayMatching.
+// Returns a *ParseError if the expression cannot be parsed into separate elements.
+func NewSchedule(expression string, opts ...ScheduleOption) (Schedule, error) {
 	s := Schedule{
-		expression: expression,
+		expression: strings.TrimSpace(expression),
 		elements:   make([]element, 6),
 	}
 
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	if err := s.extractLocation(); err != nil { // the location name is case-sensitive, so it must be extracted before normalizing
+		return Schedule{}, locateError(expression, err)
+	}
+	if err := s.extractCalendar(); err != nil { // calendar names are case-sensitive as well
+		return Schedule{}, locateError(expression, err)
+	}
+	if err := s.extractDays(); err != nil {
+		return Schedule{}, locateError(expression, err)
+	}
 	s.replaceTemplates()              // first replace all templates to literal cron schedules
 	s.normalize()                     // normalize the expression to valid cron characters
 	if err := s.parse(); err != nil { // parse the different elements in the schedule
-		return Schedule{}, err
+		return Schedule{}, locateError(expression, err)
 	}
 	return s, nil
 }
 
 // Schedule defines a cron schedule based on a cron expression.
 // Schedule should always be created using NewSchedule for proper initialization.
+//
+// The elements of the schedule are matched against the wall clock in the location of the schedule.
+// If no location is set, the location of the input time is used.
+// When the clocks are set forward, the wall clock times that are skipped are due once, at the moment of the transition.
+// When the clocks are set back, the wall clock times that are repeated are only due the first time they occur.
+//
+// H tokens, such as H, H/15 and H(0-29), resolve to values derived from the seed of the schedule.
+// This spreads schedules with the same expression over time, while every schedule keeps its own stable times.
+//
+// As in Vixie cron, the schedule is due when either the day or the weekday matches, if both of them are restricted.
+// An element is restricted unless it starts with * or is ?. Use WithStrictDayMatching to require both of them to match.
+//
+// If the schedule has a calendar, occurrences on days excluded by the calendar are skipped or moved according to the calendar policy.
+// Moved occurrences keep their wall clock time.
 type Schedule struct {
-	expression string
-	elements   []element
+	expression     string
+	elements       []element
+	location       *time.Location
+	strictDays     bool
+	calendar       *Calendar
+	calendarPolicy CalendarPolicy
+	seed           string
+}
+
+// Calendar returns the calendar applied to the schedule and its policy, or nil if the schedule has no calendar.
+func (s Schedule) Calendar() (*Calendar, CalendarPolicy) {
+	return s.calendar, s.calendarPolicy
 }
 
 // IsDue checks if input t matches the cron schedule defined by the expression.
-func (s *Schedule) IsDue(t time.Time) bool {
-	var o bool
-	for _, e := range s.elements {
-		if o = e.trigger(t); !o {
+func (s Schedule) IsDue(t time.Time) bool {
+	t = s.in(t).Truncate(time.Second)
+	if s.calendar == nil {
+		return s.isDue(t)
+	}
+
+	w := wallClock(t)
+	day := startOfDay(w)
+	if s.calendar.Excludes(day) {
+		return false
+	}
+	if s.isDue(t) {
+		return true
+	}
+
+	// Occurrences on the excluded days right before or after the day of t are moved to the day of t.
+	step := -1
+	switch s.calendarPolicy {
+	case CalendarNext:
+	case CalendarPrevious:
+		step = 1
+	default:
+		return false
+	}
+
+	for i := 1; i <= calendarSearchDays; i++ {
+		source := day.AddDate(0, 0, i*step)
+		if !s.calendar.Excludes(source) {
 			break
 		}
+		if s.matches(source.Add(w.Sub(day))) {
+			return true
+		}
+	}
+	return false
+}
+
+// Location returns the location in which the schedule is evaluated, or nil if the location of the input time is used.
+func (s Schedule) Location() *time.Location {
+	return s.location
+}
+
+// Next returns the first time after the input time at which the schedule is due.
+// Returns false if the schedule will not be due within the search horizon.
+func (s Schedule) Next(after time.Time) (time.Time, bool) {
+	if len(s.elements) == 0 {
+		return time.Time{}, false
+	}
+
+	next := s.nextWall
+	if s.calendar != nil {
+		next = s.nextCalendarWall
+	}
+
+	after = s.in(after)
+	w := wallClock(after).Add(time.Second)
+	for {
+		var ok bool
+		if w, ok = next(w); !ok {
+			return time.Time{}, false
+		}
+
+		if t := fromWallClock(w, after.Location()); t.After(after) {
+			return t, true
+		}
+		w = w.Add(time.Second)
+	}
+}
+
+// NextN returns at most n consecutive times after the input time at which the schedule is due.
+func (s Schedule) NextN(after time.Time, n int) []time.Time {
+	output := make([]time.Time, 0, max(n, 0))
+	for i := 0; i < n; i++ {
+		t, ok := s.Next(after)
+		if !ok {
+			break
+		}
+		output = append(output, t)
+		after = t
+	}
+	return output
+}
+
+// Prev returns the last time before the input time at which the schedule was due.
+// Returns false if the schedule was not due within the search horizon.
+func (s Schedule) Prev(before time.Time) (time.Time, bool) {
+	if len(s.elements) == 0 {
+		return time.Time{}, false
+	}
+
+	prev := s.prevWall
+	if s.calendar != nil {
+		prev = s.prevCalendarWall
+	}
+
+	before = s.in(before)
+	w := wallClock(before.Add(-time.Nanosecond))
+	for {
+		var ok bool
+		if w, ok = prev(w); !ok {
+			return time.Time{}, false
+		}
+
+		if t := fromWallClock(w, before.Location()); t.Before(before) {
+			return t, true
+		}
+		w = w.Add(-time.Second)
 	}
-	return o
 }
 
 // String returns the schedule expression as a string.
-func (s *Schedule) String() string {
-	return s.expression
+// If the schedule has a location, the expression is prefixed with CRON_TZ=<location>.
+// If the schedule has a named calendar, the expression is prefixed with CAL=<name>:<policy>.
+// The calendar must be registered using RegisterCalendar to parse the expression again.
+// If the schedule uses strict day matching, the expression is prefixed with DAYS=strict.
+func (s Schedule) String() string {
+	var prefix string
+	if s.location != nil {
+		prefix = "CRON_TZ=" + s.location.String() + " "
+	}
+	if s.calendar != nil && s.calendar.name != "" {
+		prefix += "CAL=" + s.calendar.name + ":" + s.calendarPolicy.String() + " "
+	}
+	if s.strictDays {
+		prefix += "DAYS=strict "
+	}
+	return prefix + s.expression
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the expression of the schedule as returned by String.
+// Returns an error if the schedule has a calendar which is not registered using RegisterCalendar,
+// as the expression cannot be parsed into the same schedule again.
+func (s Schedule) MarshalText() ([]byte, error) {
+	if s.calendar != nil {
+		if c, found := LookupCalendar(s.calendar.name); !found || !c.equal(*s.calendar) {
+			return nil, fmt.Errorf("cannot marshal schedule %s: calendar %q is not registered", s.expression, s.calendar.name)
+		}
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the expression in text.
+// The seed of s is kept, as it is not part of the expression, and so is strict day matching unless the expression sets it.
+func (s *Schedule) UnmarshalText(text []byte) error {
+	opts := []ScheduleOption{WithSeed(s.seed)}
+	if s.strictDays {
+		opts = append(opts, WithStrictDayMatching())
+	}
+
+	parsed, err := NewSchedule(string(text), opts...)
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// dayMatches checks if the day and weekday elements align with the date of t.
+// If both elements are restricted, only one of them must align unless the schedule uses strict day matching.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	day, weekday := s.element(positionDay), s.element(positionWeekday)
+	if !s.strictDays && day.restricted() && weekday.restricted() {
+		return day.trigger(t) || weekday.trigger(t)
+	}
+	return day.trigger(t) && weekday.trigger(t)
+}
+
+// extractCalendar removes the CAL= prefix from the expression and looks up the calendar it refers to.
+// Returns an error if the calendar is not registered or the policy is unknown.
+func (s *Schedule) extractCalendar() error {
+	m := reCalendar.FindStringSubmatch(s.expression)
+	if m == nil {
+		return nil
+	}
+
+	c, found := LookupCalendar(m[1])
+	if !found {
+		return newExpressionError(strings.TrimSpace(m[0]), 0, ReasonUnknownCalendar, "unknown calendar %s", m[1])
+	}
+
+	policy := CalendarSkip
+	if m[2] != "" {
+		i := slices.Index(calendarPolicyStrings, strings.ToLower(m[2]))
+		if i < 0 {
+			return newExpressionError(strings.TrimSpace(m[0]), 0, ReasonUnknownCalendar, "invalid calendar policy %s, expected one of %s", m[2], strings.Join(calendarPolicyStrings, ", "))
+		}
+		policy = CalendarPolicy(i)
+	}
+
+	s.calendar = &c
+	s.calendarPolicy = policy
+	s.expression = s.expression[len(m[0]):]
+	return nil
+}
+
+// extractDays removes the DAYS= prefix from the expression and sets the day matching of the schedule.
+// Returns an error if the day matching is not strict.
+func (s *Schedule) extractDays() error {
+	m := reDays.FindStringSubmatch(s.expression)
+	if m == nil {
+		return nil
+	}
+
+	if !strings.EqualFold(m[1], "strict") {
+		return newExpressionError(strings.TrimSpace(m[0]), 0, ReasonUnknownLiteral, "invalid day matching %s, expected strict", m[1])
+	}
+	s.strictDays = true
+	s.expression = s.expression[len(m[0]):]
+	return nil
+}
+
+// extractLocation removes the CRON_TZ= or TZ= prefix from the expression and loads the location it refers to.
+// Returns an error if the location cannot be loaded.
+func (s *Schedule) extractLocation() error {
+	m := reLocation.FindStringSubmatch(s.expression)
+	if m == nil {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(m[1])
+	if err != nil {
+		return newExpressionError(strings.TrimSpace(m[0]), 0, ReasonInvalidLocation, "invalid location %s: %s", m[1], err.Error())
+	}
+	s.location = loc
+	s.expression = s.expression[len(m[0]):]
+	return nil
+}
+
+// element returns the element for position p.
+// Positions which are not defined in the expression, such as the year, match every value.
+func (s *Schedule) element(p position) *element {
+	if int(p) < len(s.elements) {
+		return &s.elements[p]
+	}
+	return &wildcards[p]
+}
+
+// isDue checks if t, in the location of the schedule and truncated to the second, matches the schedule without its calendar.
+func (s *Schedule) isDue(t time.Time) bool {
+	w := wallClock(t)
+	if s.matches(w) {
+		// The wall clock time is not due when it occurs for the second time, because the clocks were set back.
+		start, _ := t.ZoneBounds()
+		repeated := repeatedAfter(start)
+		return repeated == 0 || !t.Before(start.Add(repeated))
+	}
+
+	// When the clocks are set forward, t is due if any of the skipped wall clock times match the schedule.
+	if gap := skippedAt(t); gap > 0 {
+		if p, ok := s.prevWall(w.Add(-time.Second)); ok {
+			return !p.Before(w.Add(-gap))
+		}
+	}
+	return false
+}
+
+// in returns t in the location of the schedule.
+func (s *Schedule) in(t time.Time) time.Time {
+	if s.location == nil {
+		return t
+	}
+	return t.In(s.location)
+}
+
+// span implements spanner, returning the second, minute or hour around t during which the schedule is due.
+// A schedule with a wildcard second is due during the whole minute, and with a wildcard minute as well during the whole hour.
+func (s Schedule) span(t time.Time) (time.Time, time.Time) {
+	unit := time.Second
+	if s.element(positionSecond).wildcard() {
+		_, offset := s.in(t).Zone()
+		switch {
+		case s.element(positionMinute).wildcard() && offset%3600 == 0:
+			unit = time.Hour
+		case offset%60 == 0:
+			unit = time.Minute
+		}
+	}
+
+	start := t.Truncate(unit)
+	return start, start.Add(unit)
+}
+
+// matches checks if wall clock time w matches all elements of the schedule.
+func (s *Schedule) matches(w time.Time) bool {
+	if len(s.elements) == 0 {
+		return false
+	}
+
+	year, month, day := w.Date()
+	hour, minute, second := w.Clock()
+	values := [...]int{second, minute, hour, day, int(month), int(w.Weekday()), year}
+
+	for i := range s.elements {
+		e := &s.elements[i]
+		if e.p == positionDay || e.p == positionWeekday { // day and weekday are checked together
+			continue
+		}
+
+		if !e.match(values[e.p]) {
+			return false
+		}
+	}
+	return s.dayMatches(w)
+}
+
+// moveDay returns the day to which the occurrences on the day of wall clock time w are moved by the calendar.
+// Returns false if the occurrences are skipped.
+func (s *Schedule) moveDay(w time.Time) (time.Time, bool) {
+	day := startOfDay(w)
+	if !s.calendar.Excludes(day) {
+		return day, true
+	}
+
+	var step int
+	switch s.calendarPolicy {
+	case CalendarNext:
+		step = 1
+	case CalendarPrevious:
+		step = -1
+	default:
+		return time.Time{}, false
+	}
+
+	for i := 0; i < calendarSearchDays; i++ {
+		if day = day.AddDate(0, 0, step); !s.calendar.Excludes(day) {
+			return day, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// nextCalendarWall returns the first wall clock time, starting at w, at which the schedule is due after applying its calendar.
+// Moving occurrences never changes their order across days, so the search stops at the first day with a due time.
+// Within that day, moved occurrences can be due before the occurrences of the day itself.
+func (s *Schedule) nextCalendarWall(w time.Time) (time.Time, bool) {
+	day := startOfDay(w)
+	limit := w.AddDate(searchYears, 0, 0)
+
+	// Occurrences on the excluded days right before w can be moved past w.
+	cursor := day
+	if s.calendarPolicy == CalendarNext {
+		for i := 0; i < calendarSearchDays && s.calendar.Excludes(cursor.AddDate(0, 0, -1)); i++ {
+			cursor = cursor.AddDate(0, 0, -1)
+		}
+	}
+
+	var (
+		best, bestDay time.Time
+		found         bool
+	)
+	for cursor.Before(limit) {
+		o, ok := s.nextWall(cursor)
+		if !ok {
+			break
+		}
+
+		source := startOfDay(o)
+		moved, ok := s.moveDay(o)
+		if !ok {
+			cursor = source.AddDate(0, 0, 1)
+			continue
+		}
+		if found && moved.After(bestDay) {
+			break
+		}
+
+		switch candidate := moved.Add(o.Sub(source)); {
+		case !candidate.Before(w):
+			if !found || candidate.Before(best) {
+				best, bestDay, found = candidate, moved, true
+			}
+			cursor = source.AddDate(0, 0, 1)
+		case moved.Equal(day):
+			cursor = source.Add(w.Sub(day)) // skip to the wall clock time of w on the source day
+		default:
+			cursor = o.Add(time.Second)
+		}
+	}
+	return best, found
+}
+
+// prevCalendarWall returns the last wall clock time, starting at w and going back, at which the schedule is due after applying its calendar.
+func (s *Schedule) prevCalendarWall(w time.Time) (time.Time, bool) {
+	day := startOfDay(w)
+	limit := w.AddDate(-searchYears, 0, 0)
+
+	// Occurrences on the excluded days right after w can be moved before w.
+	cursor := day.AddDate(0, 0, 1).Add(-time.Second)
+	if s.calendarPolicy == CalendarPrevious {
+		for i := 0; i < calendarSearchDays && s.calendar.Excludes(cursor.Add(time.Second)); i++ {
+			cursor = cursor.AddDate(0, 0, 1)
+		}
+	}
+
+	var (
+		best, bestDay time.Time
+		found         bool
+	)
+	for cursor.After(limit) {
+		o, ok := s.prevWall(cursor)
+		if !ok {
+			break
+		}
+
+		source := startOfDay(o)
+		moved, ok := s.moveDay(o)
+		if !ok {
+			cursor = source.Add(-time.Second)
+			continue
+		}
+		if found && moved.Before(bestDay) {
+			break
+		}
+
+		switch candidate := moved.Add(o.Sub(source)); {
+		case !candidate.After(w):
+			if !found || candidate.After(best) {
+				best, bestDay, found = candidate, moved, true
+			}
+			cursor = source.Add(-time.Second)
+		case moved.Equal(day):
+			cursor = source.Add(w.Sub(day)) // skip to the wall clock time of w on the source day
+		default:
+			cursor = o.Add(-time.Second)
+		}
+	}
+	return best, found
+}
+
+// nextWall returns the first wall clock time, starting at w, that matches all elements of the schedule.
+// Wall clock times are expressed in UTC, so they can be walked without having to deal with zone transitions.
+func (s *Schedule) nextWall(w time.Time) (time.Time, bool) {
+	limit := w.Year() + searchYears
+	for w.Year() <= limit {
+		year, ok := s.element(positionYear).next(w.Year(), limit)
+		if !ok {
+			return time.Time{}, false
+		}
+		if year != w.Year() {
+			w = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+
+		month, ok := s.element(positionMonth).next(int(w.Month()), 12)
+		if !ok {
+			w = time.Date(w.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if month != int(w.Month()) {
+			w = time.Date(w.Year(), time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		}
+
+		if !s.dayMatches(w) {
+			w = time.Date(w.Year(), w.Month(), w.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		hour, ok := s.element(positionHour).next(w.Hour(), 23)
+		if !ok {
+			w = time.Date(w.Year(), w.Month(), w.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if hour != w.Hour() {
+			w = time.Date(w.Year(), w.Month(), w.Day(), hour, 0, 0, 0, time.UTC)
+		}
+
+		minute, ok := s.element(positionMinute).next(w.Minute(), 59)
+		if !ok {
+			w = time.Date(w.Year(), w.Month(), w.Day(), w.Hour()+1, 0, 0, 0, time.UTC)
+			continue
+		}
+		if minute != w.Minute() {
+			w = time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), minute, 0, 0, time.UTC)
+		}
+
+		second, ok := s.element(positionSecond).next(w.Second(), 59)
+		if !ok {
+			w = time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute()+1, 0, 0, time.UTC)
+			continue
+		}
+		return time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), second, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
+
+// prevWall returns the last wall clock time, starting at w and going back, that matches all elements of the schedule.
+// Wall clock times are expressed in UTC, so they can be walked without having to deal with zone transitions.
+func (s *Schedule) prevWall(w time.Time) (time.Time, bool) {
+	limit := w.Year() - searchYears
+	for w.Year() >= limit {
+		year, ok := s.element(positionYear).prev(w.Year(), limit)
+		if !ok {
+			return time.Time{}, false
+		}
+		if year != w.Year() {
+			w = time.Date(year, time.December, 31, 23, 59, 59, 0, time.UTC)
+		}
+
+		month, ok := s.element(positionMonth).prev(int(w.Month()), 1)
+		if !ok {
+			w = time.Date(w.Year(), time.January, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+			continue
+		}
+		if month != int(w.Month()) {
+			w = time.Date(w.Year(), time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+		}
+
+		if !s.dayMatches(w) {
+			w = time.Date(w.Year(), w.Month(), w.Day(), 0, 0, 0, 0, time.UTC).Add(-time.Second)
+			continue
+		}
+
+		hour, ok := s.element(positionHour).prev(w.Hour(), 0)
+		if !ok {
+			w = time.Date(w.Year(), w.Month(), w.Day(), 0, 0, 0, 0, time.UTC).Add(-time.Second)
+			continue
+		}
+		if hour != w.Hour() {
+			w = time.Date(w.Year(), w.Month(), w.Day(), hour, 59, 59, 0, time.UTC)
+		}
+
+		minute, ok := s.element(positionMinute).prev(w.Minute(), 0)
+		if !ok {
+			w = time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), 0, 0, 0, time.UTC).Add(-time.Second)
+			continue
+		}
+		if minute != w.Minute() {
+			w = time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), minute, 59, 0, time.UTC)
+		}
+
+		second, ok := s.element(positionSecond).prev(w.Second(), 0)
+		if !ok {
+			w = time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), 0, 0, time.UTC).Add(-time.Second)
+			continue
+		}
+		return time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), second, 0, time.UTC), true
+	}
+	return time.Time{}, false
 }
 
 // normalize replaces all strings and literals into cron characters.
 // It does not parse or validate the expression!
 func (s *Schedule) normalize() {
-	// Replace all spaces with a single space
-	s.expression = reSpace.ReplaceAllString(s.expression, " ")
-
-	// Transform string to uppercase before replacing characters to numbers
-	s.expression = strings.ToUpper(s.expression)
-
-	// Replace weekday literals to numbers
-	s.expression = cronWeekdayLiterals.Replace(s.expression)
-	// Replace month literals to numbers
-	s.expression = cronMonthLiterals.Replace(s.expression)
+	s.expression = normalizeExpression(s.expression)
 }
 
 // parse converts the schedule expression into cron elements.
@@ -166,7 +738,14 @@ func (s *Schedule) parse() error {
 
 	for i, expression := range elements {
 		var e element
-		e, err = newElement(expression, position(i))
+		if e.expression, err = resolveHash(expression, position(i), s.seed); err == nil {
+			e, err = newElement(e.expression, position(i))
+		}
+
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			pe.Token = expression
+		}
 		if err != nil {
 			return err
 		}
@@ -191,14 +770,158 @@ func (s *Schedule) standardize() ([]string, error) {
 	// Expect at least 5 elements: minute, hour, day, month, weekday
 	// Maximum 7 elements: second, minute, hour ,day, month, weekday, year
 	if count < 5 || count > 7 {
-		return nil, fmt.Errorf("invalid element count, got %d, expected 5-7 elements separated by space", count)
+		return nil, newExpressionError(s.expression, 0, ReasonWrongFieldCount, "invalid element count, got %d, expected 5-7 elements separated by space", count)
 	}
 
 	// If there are only 5 elements, prepend the expression with a 0 for the seconds position.
-	// If there are 6 elements and the last element matches the regular expression for a year, prepend the expression with 0 for the seconds position.
+	// If there are 6 elements and the last element only holds years of at least 4 digits, such as 2025 or 2025-2030, prepend the expression with 0 for the seconds position.
 	if (count == 5) || (count == 6 && reYear.MatchString(segments[5])) {
 		segments = append([]string{"0"}, segments...)
 	}
 
 	return segments, nil
 }
+
+// field is a single element or prefix of an expression, as it was written.
+type field struct {
+	text   string
+	offset int
+}
+
+// fields returns the elements of the expression by position, as they were written.
+// Also returns the prefixes of the expression, such as the location and calendar.
+// Returns no elements if the expression is a template or has an invalid number of elements.
+func fields(expression string) (map[position]field, []field) {
+	var (
+		elements = make(map[position]field)
+		prefixes []field
+		tokens   []field
+	)
+
+	for _, loc := range reToken.FindAllStringIndex(expression, -1) {
+		f := field{text: expression[loc[0]:loc[1]], offset: loc[0]}
+		if len(tokens) == 0 && rePrefix.MatchString(f.text) {
+			prefixes = append(prefixes, f)
+			continue
+		}
+		tokens = append(tokens, f)
+	}
+
+	count := len(tokens)
+	if count < 5 || count > 7 {
+		return elements, prefixes
+	}
+
+	// Expressions without seconds start at the minute position, as in standardize.
+	first := positionSecond
+	if count == 5 || (count == 6 && reYear.MatchString(tokens[count-1].text)) {
+		first = positionMinute
+	}
+
+	for i, f := range tokens {
+		elements[first+position(i)] = f
+	}
+	return elements, prefixes
+}
+
+// locateError sets the token and span of a ParseError, using the expression as it was written.
+// Other errors are returned unchanged.
+func locateError(expression string, err error) error {
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		return err
+	}
+
+	elements, prefixes := fields(expression)
+	switch {
+	case pe.Position != "":
+		for p, f := range elements {
+			if p.String() == pe.Position {
+				pe.Token, pe.Start = f.text, f.offset
+			}
+		}
+	case pe.Reason == ReasonWrongFieldCount: // the span covers all elements, after the prefixes
+		rest := expression
+		if len(prefixes) > 0 {
+			last := prefixes[len(prefixes)-1]
+			rest = expression[last.offset+len(last.text):]
+		}
+		pe.Start = len(expression) - len(strings.TrimLeftFunc(rest, unicode.IsSpace))
+		pe.Token = strings.TrimSpace(rest)
+	default:
+		for _, f := range prefixes {
+			if f.text == pe.Token {
+				pe.Token, pe.Start = f.text, f.offset
+			}
+		}
+	}
+	pe.End = pe.Start + len(pe.Token)
+	return pe
+}
+
+// normalizeExpression replaces all strings and literals in expression into cron characters.
+func normalizeExpression(expression string) string {
+	// Replace all spaces with a single space
+	expression = reSpace.ReplaceAllString(expression, " ")
+
+	// Transform string to uppercase before replacing characters to numbers
+	expression = strings.ToUpper(expression)
+
+	// Replace weekday literals to numbers
+	expression = cronWeekdayLiterals.Replace(expression)
+	// Replace month literals to numbers
+	return cronMonthLiterals.Replace(expression)
+}
+
+// startOfDay returns the start of the day of wall clock time w.
+func startOfDay(w time.Time) time.Time {
+	return time.Date(w.Year(), w.Month(), w.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// wallClock returns the wall clock reading of t, truncated to the second and expressed in UTC.
+func wallClock(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+	return time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+}
+
+// fromWallClock returns the time in location loc for the wall clock reading w.
+// If w occurs twice because the clocks were set back, the first occurrence is returned.
+// If w does not occur because the clocks were set forward, the moment of the transition is returned.
+func fromWallClock(w time.Time, loc *time.Location) time.Time {
+	t := time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), 0, loc)
+
+	start, _ := t.ZoneBounds()
+	if !wallClock(t).Equal(w) {
+		return start
+	}
+
+	if repeated := repeatedAfter(start); repeated > 0 {
+		if earlier := t.Add(-repeated); earlier.Before(start) && wallClock(earlier).Equal(w) {
+			return earlier
+		}
+	}
+	return t
+}
+
+// repeatedAfter returns how much wall clock time is repeated after zone transition start, because the clocks were set back.
+func repeatedAfter(start time.Time) time.Duration {
+	if start.IsZero() {
+		return 0
+	}
+
+	_, before := start.Add(-time.Second).Zone()
+	_, after := start.Zone()
+	return time.Duration(before-after) * time.Second
+}
+
+// skippedAt returns how much wall clock time was skipped at t, if the clocks were set forward at exactly t.
+func skippedAt(t time.Time) time.Duration {
+	if start, _ := t.ZoneBounds(); !start.Equal(t) {
+		return 0
+	}
+
+	_, before := t.Add(-time.Second).Zone()
+	_, after := t.Zone()
+	return time.Duration(after-before) * time.Second
+}
@@ -0,0 +1,39 @@
+package cron
+
+import "time"
+
+type ScheduleOption func(*Schedule)
+
+// WithLocation evaluates the schedule in location loc, regardless of the location of the times passed to the schedule.
+// A location set in the expression using a CRON_TZ= or TZ= prefix takes precedence.
+func WithLocation(loc *time.Location) ScheduleOption {
+	return func(s *Schedule) {
+		s.location = loc
+	}
+}
+
+// WithStrictDayMatching requires both the day and weekday elements to match when both of them are restricted.
+// By default, the schedule is due when either of them matches, as in Vixie cron.
+func WithStrictDayMatching() ScheduleOption {
+	return func(s *Schedule) {
+		s.strictDays = true
+	}
+}
+
+// WithCalendar applies calendar c to the schedule, skipping or moving the occurrences on excluded days according to policy.
+// A calendar set in the expression using a CAL= prefix takes precedence.
+func WithCalendar(c Calendar, policy CalendarPolicy) ScheduleOption {
+	return func(s *Schedule) {
+		s.calendar = &c
+		s.calendarPolicy = policy
+	}
+}
+
+// WithSeed resolves the H tokens in the expression using seed, such as the UUID of the job using the schedule.
+// Schedules with the same expression and seed are always due at the same times.
+// The seed is not part of the expression, so the same seed must be used when parsing the expression again.
+func WithSeed(seed string) ScheduleOption {
+	return func(s *Schedule) {
+		s.seed = seed
+	}
+}
@@ -1,6 +1,9 @@
 package cron
 
 import (
+	"encoding/json"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -47,6 +50,8 @@ func TestSchedule_IsDueValid(t *testing.T) {
 	}{
 		{"* * * * * *", "20060102150405", true},
 		{"5 * * * * *", "20060102150405", true},
+		{"0 0 * * 7", "20060101000000", true},
+		{"* * * * 5-7", "20060101000000", true},
 		{"* 4 * * * *", "20060102150405", true},
 		{"* * 15 * * *", "20060102150405", true},
 		{"* * * 2 * *", "20060102150405", true},
@@ -64,6 +69,7 @@ func TestSchedule_IsDueValid(t *testing.T) {
 		{"* * * * * 2 *", "20060102150405", false},
 		// Different time value --> second == 0
 		{"* * * * 2", "20060102150400", false},
+		{"* * * * 5-7", "20060102150400", false},
 		{"5 * * * * * *", "20060102150400", false},
 	}
 
@@ -176,6 +182,37 @@ func TestSchedule_StandardizeValid(t *testing.T) {
 	}
 }
 
+func TestSchedule_StandardizeYear(t *testing.T) {
+	var tests = []struct {
+		expression string
+		wanted     string
+	}{
+		{"30 2 * 3 1-5", "0 30 2 * 3 1-5"},
+		{"0 30 2 * 3 1-5", "0 30 2 * 3 1-5"},
+		{"0 30 2 * 3 5", "0 30 2 * 3 5"},
+		{"0 30 2 * 3 */2", "0 30 2 * 3 */2"},
+		{"30 2 * 3 1-5 2025", "0 30 2 * 3 1-5 2025"},
+		{"30 2 * 3 1-5 2025-2030", "0 30 2 * 3 1-5 2025-2030"},
+		{"30 2 * 3 1-5 2025,2027-2030/3", "0 30 2 * 3 1-5 2025,2027-2030/3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			s := Schedule{
+				expression: tt.expression,
+			}
+			segments, err := s.standardize()
+			if err != nil {
+				t.Fatalf("cannot standardize %s: %v", tt.expression, err)
+			}
+
+			if got := strings.Join(segments, " "); got != tt.wanted {
+				t.Errorf("standardize(%s) = %s, wanted %s", tt.expression, got, tt.wanted)
+			}
+		})
+	}
+}
+
 func TestSchedule_ParseValid(t *testing.T) {
 	var tests = []struct {
 		expression string
@@ -183,6 +220,8 @@ func TestSchedule_ParseValid(t *testing.T) {
 	}{
 		{"* * * * *", true},
 		{"* * * * * * *", true},
+		{"0 0 * * 7", true},
+		{"* * * * 5-7", true},
 
 		{"* * * *", false},
 		{"* * * * * * * *", false},
@@ -252,3 +291,561 @@ func TestSchedule_Function(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedule_Next(t *testing.T) {
+	var tests = []struct {
+		expression string
+		after      string
+		wanted     string
+		ok         bool
+	}{
+		{"* * * * * *", "20060102150405", "20060102150406", true},
+		{"* * * * *", "20060102150405", "20060102150500", true},
+		{"30 * * * *", "20060102150405", "20060102153000", true},
+		{"0 0 * * *", "20060102150405", "20060103000000", true},
+		{"0 0 1 * *", "20060102150405", "20060201000000", true},
+		{"0 0 1 1 *", "20060102150405", "20070101000000", true},
+		{"0 12 * * 0", "20060102150405", "20060108120000", true},
+		{"*/15 * * * *", "20060102150405", "20060102151500", true},
+		{"0 9-17 * * *", "20060102180000", "20060103090000", true},
+		{"0 0 29 2 *", "20060102150405", "20080229000000", true},
+		{"0 0 31 12 * 2030", "20060102150405", "20301231000000", true},
+		{"59 59 23 31 12 *", "20061231235958", "20061231235959", true},
+		{"*/7 * * * * *", "20060102150405", "20060102150407", true},
+		{"*/7 * * * * *", "20060102150456", "20060102150500", true},
+		{"0 10/15 * * * *", "20060102151000", "20060102152500", true},
+		{"0-30/20 * * * *", "20060102152000", "20060102160000", true},
+		{"0 0 */2 * *", "20060102150405", "20060103000000", true},
+		{"0 0 1-5,10-20/5 * *", "20060105150405", "20060110000000", true},
+		{"0 0 1-5,10-20/5 * *", "20060110150405", "20060115000000", true},
+		{"0 0 L * *", "20060102150405", "20060131000000", true},
+		{"0 0 L 2 *", "20060102150405", "20060228000000", true},
+		{"0 0 15W * *", "20060302150405", "20060315000000", true},
+		{"0 0 15W * *", "20060316000000", "20060414000000", true},
+		{"0 0 LW * *", "20060402150405", "20060428000000", true},
+		{"0 0 ? * 2#2", "20060102150405", "20060110000000", true},
+		{"0 0 ? * FRI#3", "20060102150405", "20060120000000", true},
+		{"0 0 ? * 5L", "20060102150405", "20060127000000", true},
+		{"0 0 * * 7", "20060102150405", "20060108000000", true},
+		{"* * * * 5-7", "20060102150405", "20060106000000", true},
+		{"* * * * 5-7", "20060107235930", "20060108000000", true},
+		{"* * * * 5-7", "20060108235930", "20060113000000", true},
+		{"0 0 ? * 7L", "20060102150405", "20060129000000", true},
+		{"0 0 ? * 7#1", "20060102150405", "20060205000000", true},
+
+		{"0 0 31 2 *", "20060102150405", "", false},
+		{"0 0 1 1 * 2005", "20060102150405", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+"_"+tt.after, func(t *testing.T) {
+			s, err := NewSchedule(tt.expression)
+			if err != nil {
+				t.Fatalf("invalid expression %s", tt.expression)
+			}
+
+			after, _ := time.Parse("20060102150405", tt.after)
+			next, ok := s.Next(after)
+			if ok != tt.ok {
+				t.Fatalf("expected %t for %s, got %t", tt.ok, tt.expression, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if wanted, _ := time.Parse("20060102150405", tt.wanted); !next.Equal(wanted) {
+				t.Errorf("got %s, expected %s", next, wanted)
+			}
+
+			if !s.IsDue(next) {
+				t.Errorf("schedule should be due at %s", next)
+			}
+		})
+	}
+}
+
+func TestSchedule_Prev(t *testing.T) {
+	var tests = []struct {
+		expression string
+		before     string
+		wanted     string
+		ok         bool
+	}{
+		{"* * * * * *", "20060102150405", "20060102150404", true},
+		{"* * * * *", "20060102150405", "20060102150400", true},
+		{"* * * * *", "20060102150400", "20060102150300", true},
+		{"30 * * * *", "20060102150405", "20060102143000", true},
+		{"0 0 * * *", "20060102150405", "20060102000000", true},
+		{"0 0 1 * *", "20060102150405", "20060101000000", true},
+		{"0 0 1 1 *", "20060101000000", "20050101000000", true},
+		{"0 12 * * 0", "20060102150405", "20060101120000", true},
+		{"*/15 * * * *", "20060102150405", "20060102150000", true},
+		{"0 9-17 * * *", "20060102080000", "20060101170000", true},
+		{"0 0 29 2 *", "20060102150405", "20040229000000", true},
+
+		{"0 0 31 2 *", "20060102150405", "", false},
+		{"0 0 1 1 * 2030", "20060102150405", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+"_"+tt.before, func(t *testing.T) {
+			s, err := NewSchedule(tt.expression)
+			if err != nil {
+				t.Fatalf("invalid expression %s", tt.expression)
+			}
+
+			before, _ := time.Parse("20060102150405", tt.before)
+			prev, ok := s.Prev(before)
+			if ok != tt.ok {
+				t.Fatalf("expected %t for %s, got %t", tt.ok, tt.expression, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if wanted, _ := time.Parse("20060102150405", tt.wanted); !prev.Equal(wanted) {
+				t.Errorf("got %s, expected %s", prev, wanted)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextN(t *testing.T) {
+	s, err := NewSchedule("0,30 * * * *")
+	if err != nil {
+		t.Fatalf("invalid expression")
+	}
+
+	after, _ := time.Parse("20060102150405", "20060102150405")
+	wanted := []string{"20060102153000", "20060102160000", "20060102163000", "20060102170000"}
+
+	output := s.NextN(after, len(wanted))
+	if len(output) != len(wanted) {
+		t.Fatalf("got %d times, expected %d", len(output), len(wanted))
+	}
+
+	for i, w := range wanted {
+		if v, _ := time.Parse("20060102150405", w); !output[i].Equal(v) {
+			t.Errorf("got %s, expected %s", output[i], v)
+		}
+	}
+}
+
+func TestNewSchedule_Location(t *testing.T) {
+	brussels, _ := time.LoadLocation("Europe/Brussels")
+
+	var tests = []struct {
+		expression string
+		opts       []ScheduleOption
+		location   *time.Location
+		wanted     string
+		success    bool
+	}{
+		{"CRON_TZ=Europe/Brussels 0 2 * * *", nil, brussels, "CRON_TZ=Europe/Brussels 0 2 * * *", true},
+		{"TZ=Europe/Brussels 0 2 * * *", nil, brussels, "CRON_TZ=Europe/Brussels 0 2 * * *", true},
+		{"CRON_TZ=UTC @daily", nil, time.UTC, "CRON_TZ=UTC 0 0 * * *", true},
+		{"0 2 * * *", []ScheduleOption{WithLocation(brussels)}, brussels, "CRON_TZ=Europe/Brussels 0 2 * * *", true},
+		{"CRON_TZ=UTC 0 2 * * *", []ScheduleOption{WithLocation(brussels)}, time.UTC, "CRON_TZ=UTC 0 2 * * *", true},
+		{"0 2 * * *", nil, nil, "0 2 * * *", true},
+
+		{"CRON_TZ=Nowhere/Atlantis 0 2 * * *", nil, nil, "", false},
+		{"CRON_TZ=UTC", nil, nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			s, err := NewSchedule(tt.expression, tt.opts...)
+			if (err == nil) != tt.success {
+				t.Fatalf("unexpected result for %s: %v", tt.expression, err)
+			}
+
+			if !tt.success {
+				return
+			}
+
+			if (s.Location() == nil) != (tt.location == nil) || (tt.location != nil && s.Location().String() != tt.location.String()) {
+				t.Errorf("got location %v, expected %v", s.Location(), tt.location)
+			}
+
+			if s.String() != tt.wanted {
+				t.Errorf("got %s, expected %s", s.String(), tt.wanted)
+			}
+
+			r, err := NewSchedule(s.String())
+			if err != nil || r.String() != s.String() {
+				t.Errorf("expression %s does not round-trip", s.String())
+			}
+		})
+	}
+}
+
+func TestSchedule_NextLocation(t *testing.T) {
+	var tests = []struct {
+		expression string
+		after      string
+		wanted     []string
+	}{
+		{"CRON_TZ=Europe/Brussels 0 2 * * *", "2026-01-10T12:00:00Z", []string{"2026-01-11T01:00:00Z", "2026-01-12T01:00:00Z"}},
+		{"CRON_TZ=America/New_York 0 2 * * *", "2026-01-10T12:00:00Z", []string{"2026-01-11T07:00:00Z", "2026-01-12T07:00:00Z"}},
+		// Clocks are set forward from 02:00 to 03:00, the skipped times are due once at the transition
+		{"CRON_TZ=Europe/Brussels 30 2 * * *", "2026-03-28T12:00:00Z", []string{"2026-03-29T01:00:00Z", "2026-03-30T00:30:00Z"}},
+		{"CRON_TZ=Europe/Brussels */20 2-3 * * *", "2026-03-29T00:00:00Z", []string{"2026-03-29T01:00:00Z", "2026-03-29T01:20:00Z", "2026-03-29T01:40:00Z", "2026-03-30T00:00:00Z"}},
+		// Clocks are set back from 03:00 to 02:00, the repeated times are only due the first time
+		{"CRON_TZ=Europe/Brussels 30 2 * * *", "2026-10-24T12:00:00Z", []string{"2026-10-25T00:30:00Z", "2026-10-26T01:30:00Z"}},
+		{"CRON_TZ=Europe/Brussels 0,30 2-3 * * *", "2026-10-24T23:45:00Z", []string{"2026-10-25T00:00:00Z", "2026-10-25T00:30:00Z", "2026-10-25T02:00:00Z", "2026-10-25T02:30:00Z"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+"_"+tt.after, func(t *testing.T) {
+			s, err := NewSchedule(tt.expression)
+			if err != nil {
+				t.Fatalf("invalid expression %s: %v", tt.expression, err)
+			}
+
+			after, _ := time.Parse(time.RFC3339, tt.after)
+			output := s.NextN(after, len(tt.wanted))
+			if len(output) != len(tt.wanted) {
+				t.Fatalf("got %d times, expected %d", len(output), len(tt.wanted))
+			}
+
+			for i, w := range tt.wanted {
+				wanted, _ := time.Parse(time.RFC3339, w)
+				if !output[i].Equal(wanted) {
+					t.Errorf("got %s, expected %s", output[i].UTC(), wanted)
+				}
+
+				if !s.IsDue(output[i]) {
+					t.Errorf("schedule should be due at %s", output[i].UTC())
+				}
+
+				if prev, ok := s.Prev(output[i].Add(time.Second)); !ok || !prev.Equal(output[i]) {
+					t.Errorf("got previous %s, expected %s", prev.UTC(), output[i].UTC())
+				}
+			}
+		})
+	}
+}
+
+func TestSchedule_IsDueLocation(t *testing.T) {
+	var tests = []struct {
+		expression string
+		time       string
+		wanted     bool
+	}{
+		{"CRON_TZ=Europe/Brussels 0 2 * * *", "2026-01-11T01:00:00Z", true},
+		{"CRON_TZ=Europe/Brussels 0 2 * * *", "2026-01-11T02:00:00Z", false},
+		{"CRON_TZ=Europe/Brussels 30 2 * * *", "2026-03-29T01:00:00Z", true},
+		{"CRON_TZ=Europe/Brussels 30 2 * * *", "2026-03-29T01:30:00Z", false},
+		{"CRON_TZ=Europe/Brussels 30 2 * * *", "2026-10-25T00:30:00Z", true},
+		{"CRON_TZ=Europe/Brussels 30 2 * * *", "2026-10-25T01:30:00Z", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+"_"+tt.time, func(t *testing.T) {
+			s, err := NewSchedule(tt.expression)
+			if err != nil {
+				t.Fatalf("invalid expression %s: %v", tt.expression, err)
+			}
+
+			i, _ := time.Parse(time.RFC3339, tt.time)
+			if s.IsDue(i) != tt.wanted {
+				t.Errorf("expected IsDue to be %t for %s at %s", tt.wanted, tt.expression, tt.time)
+			}
+		})
+	}
+}
+
+func BenchmarkSchedule_IsDue(b *testing.B) {
+	s, _ := NewSchedule("*/5 0-30/3,45 8-18 1-15 * MON-FRI *")
+	i, _ := time.Parse("20060102150405", "20060102150405")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		s.IsDue(i.Add(time.Duration(n) * time.Second))
+	}
+}
+
+func BenchmarkSchedule_Next(b *testing.B) {
+	s, _ := NewSchedule("*/5 0-30/3,45 8-18 1-15 * MON-FRI *")
+	i, _ := time.Parse("20060102150405", "20060102150405")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		s.Next(i.Add(time.Duration(n) * time.Minute))
+	}
+}
+
+func TestSchedule_DayMatching(t *testing.T) {
+	var tests = []struct {
+		expression string
+		strict     bool
+		time       string
+		wanted     bool
+	}{
+		// 2006-01-02 is a Monday, 2006-01-09 is a Monday, 2006-01-01 is a Sunday, 2006-05-01 is a Monday
+		{"0 0 1 * MON", false, "20060101000000", true},
+		{"0 0 1 * MON", false, "20060109000000", true},
+		{"0 0 1 * MON", false, "20060110000000", false},
+		{"0 0 1 * MON", true, "20060101000000", false},
+		{"0 0 1 * MON", true, "20060109000000", false},
+		{"0 0 1 * MON", true, "20060501000000", true},
+
+		{"0 0 1 * *", false, "20060109000000", false},
+		{"0 0 * * MON", false, "20060101000000", false},
+		{"0 0 */2 * MON", false, "20060109000000", true},
+		{"0 0 */2 * MON", false, "20060102000000", false},
+		{"0 0 */2 * MON", false, "20060103000000", false},
+		{"0 0 */2 * MON", false, "20060123000000", true},
+		{"0 0 ? * MON", false, "20060101000000", false},
+		{"0 0 15W * 5L", false, "20060127000000", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+"_"+tt.time, func(t *testing.T) {
+			var opts []ScheduleOption
+			if tt.strict {
+				opts = append(opts, WithStrictDayMatching())
+			}
+
+			s, err := NewSchedule(tt.expression, opts...)
+			if err != nil {
+				t.Fatalf("invalid expression %s", tt.expression)
+			}
+
+			i, _ := time.Parse("20060102150405", tt.time)
+			if s.IsDue(i) != tt.wanted {
+				t.Errorf("expected IsDue to be %t for %s at %s", tt.wanted, tt.expression, tt.time)
+			}
+
+			if next, ok := s.Next(i.Add(-time.Second)); ok && next.Equal(i) != tt.wanted {
+				t.Errorf("expected Next to be %s: %t, got %s", tt.time, tt.wanted, next)
+			}
+		})
+	}
+}
+
+func TestNewSchedule_Calendar(t *testing.T) {
+	_ = RegisterCalendar(NewCalendar("business", WithExcludedWeekdays(time.Saturday, time.Sunday)))
+
+	var tests = []struct {
+		expression string
+		wanted     string
+		success    bool
+	}{
+		{"CAL=business 0 0 10 25 * *", "CAL=business:skip 0 0 10 25 * *", true},
+		{"CAL=business:next 0 0 10 25 * *", "CAL=business:next 0 0 10 25 * *", true},
+		{"CAL=business:Previous @daily", "CAL=business:previous 0 0 * * *", true},
+		{"CRON_TZ=Europe/Brussels CAL=business:next 0 2 * * *", "CRON_TZ=Europe/Brussels CAL=business:next 0 2 * * *", true},
+
+		{"CAL=holidays 0 2 * * *", "", false},
+		{"CAL=business:later 0 2 * * *", "", false},
+		{"CAL=business", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			s, err := NewSchedule(tt.expression)
+			if (err == nil) != tt.success {
+				t.Fatalf("unexpected result for %s: %v", tt.expression, err)
+			}
+
+			if !tt.success {
+				return
+			}
+
+			if s.String() != tt.wanted {
+				t.Errorf("got %s, expected %s", s.String(), tt.wanted)
+			}
+
+			if _, err = NewSchedule(s.String()); err != nil {
+				t.Errorf("cannot parse %s again: %v", s.String(), err)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextCalendar(t *testing.T) {
+	c := NewCalendar("payroll",
+		WithExcludedDates(time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)),
+		WithExcludedWeekdays(time.Saturday, time.Sunday))
+
+	var tests = []struct {
+		expression string
+		policy     CalendarPolicy
+		after      string
+		wanted     string
+	}{
+		{"0 0 9 25 * *", CalendarSkip, "20251201000000", "20260225090000"},
+		{"0 0 9 25 * *", CalendarNext, "20251201000000", "20251226090000"},
+		{"0 0 9 25 * *", CalendarPrevious, "20251201000000", "20251224090000"},
+		{"0 0 9 25 * *", CalendarNext, "20251001000000", "20251027090000"},
+		{"0 0 9 25 * *", CalendarPrevious, "20251001000000", "20251024090000"},
+		// Occurrences moved from before the input time can still be due after it
+		{"0 0 10 25 * *", CalendarNext, "20251027093000", "20251027100000"},
+		{"0 0 10 25 * *", CalendarNext, "20251027100000", "20251125100000"},
+		{"0 0 10 * * *", CalendarNext, "20251024100000", "20251027100000"},
+		{"0 0 10 * * *", CalendarPrevious, "20251023100000", "20251024100000"},
+		{"0 0 10 * * *", CalendarPrevious, "20251024100000", "20251027100000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+" "+tt.policy.String()+" "+tt.after, func(t *testing.T) {
+			s, err := NewSchedule(tt.expression, WithCalendar(c, tt.policy))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			after, _ := time.Parse("20060102150405", tt.after)
+			wanted, _ := time.Parse("20060102150405", tt.wanted)
+
+			got, ok := s.Next(after)
+			if !ok || !got.Equal(wanted) {
+				t.Errorf("Next(%s) = %s, %t, wanted %s", after, got, ok, wanted)
+			}
+		})
+	}
+}
+
+func TestSchedule_PrevCalendar(t *testing.T) {
+	c := NewCalendar("payroll",
+		WithExcludedDates(time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)),
+		WithExcludedWeekdays(time.Saturday, time.Sunday))
+
+	var tests = []struct {
+		expression string
+		policy     CalendarPolicy
+		before     string
+		wanted     string
+	}{
+		{"0 0 9 25 * *", CalendarSkip, "20260101000000", "20251125090000"},
+		{"0 0 9 25 * *", CalendarNext, "20260101000000", "20251226090000"},
+		{"0 0 9 25 * *", CalendarPrevious, "20260101000000", "20251224090000"},
+		// Occurrences moved from after the input time can still be due before it
+		{"0 0 10 25 * *", CalendarPrevious, "20251024120000", "20251024100000"},
+		{"0 0 10 25 * *", CalendarPrevious, "20251024100000", "20250925100000"},
+		{"0 0 10 * * *", CalendarNext, "20251027100000", "20251024100000"},
+		{"0 0 10 * * *", CalendarNext, "20251027100001", "20251027100000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+" "+tt.policy.String()+" "+tt.before, func(t *testing.T) {
+			s, err := NewSchedule(tt.expression, WithCalendar(c, tt.policy))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			before, _ := time.Parse("20060102150405", tt.before)
+			wanted, _ := time.Parse("20060102150405", tt.wanted)
+
+			got, ok := s.Prev(before)
+			if !ok || !got.Equal(wanted) {
+				t.Errorf("Prev(%s) = %s, %t, wanted %s", before, got, ok, wanted)
+			}
+		})
+	}
+}
+
+func TestSchedule_IsDueCalendar(t *testing.T) {
+	c := NewCalendar("business", WithExcludedWeekdays(time.Saturday, time.Sunday))
+
+	var tests = []struct {
+		expression string
+		policy     CalendarPolicy
+		time       string
+		wanted     bool
+	}{
+		{"0 0 10 * * *", CalendarSkip, "20251024100000", true},
+		{"0 0 10 * * *", CalendarSkip, "20251025100000", false},
+		{"0 0 10 * * *", CalendarSkip, "20251027100000", true},
+		{"0 0 10 25 * *", CalendarSkip, "20251027100000", false},
+		{"0 0 10 25 * *", CalendarNext, "20251025100000", false},
+		{"0 0 10 25 * *", CalendarNext, "20251027100000", true},
+		{"0 0 10 25 * *", CalendarNext, "20251027110000", false},
+		{"0 0 10 25 * *", CalendarNext, "20251028100000", false},
+		{"0 0 10 25 * *", CalendarPrevious, "20251024100000", true},
+		{"0 0 10 25 * *", CalendarPrevious, "20251027100000", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression+" "+tt.policy.String()+" "+tt.time, func(t *testing.T) {
+			s, err := NewSchedule(tt.expression, WithCalendar(c, tt.policy))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			at, _ := time.Parse("20060102150405", tt.time)
+
+			if got := s.IsDue(at); got != tt.wanted {
+				t.Errorf("IsDue(%s) = %t, wanted %t", at, got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestSchedule_MarshalText(t *testing.T) {
+	holidays := NewCalendar("marshal-holidays", WithExcludedDates(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	if err := RegisterCalendar(holidays); err != nil {
+		t.Fatalf("cannot register calendar: %v", err)
+	}
+
+	var tests = []struct {
+		expression string
+		opts       []ScheduleOption
+		seed       string
+	}{
+		{expression: "*/5 * * * *"},
+		{expression: "CRON_TZ=Europe/Brussels 0 30 2 * * MON-FRI *"},
+		{expression: "H H * * *", seed: "job"},
+		{expression: "0 0 1 * MON", opts: []ScheduleOption{WithStrictDayMatching()}},
+		{expression: "0 0 * * *", opts: []ScheduleOption{WithCalendar(holidays, CalendarNext), WithStrictDayMatching()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			s, err := NewSchedule(tt.expression, append(tt.opts, WithSeed(tt.seed))...)
+			if err != nil {
+				t.Fatalf("invalid expression %s: %v", tt.expression, err)
+			}
+
+			data, err := json.Marshal(s)
+			if err != nil {
+				t.Fatalf("cannot marshal schedule: %v", err)
+			}
+
+			// the seed is not part of the expression, so it must be set on the schedule to decode into
+			var decoded Schedule
+			WithSeed(tt.seed)(&decoded)
+			if err = json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("cannot unmarshal %s: %v", data, err)
+			}
+
+			if decoded.String() != s.String() {
+				t.Errorf("decoded schedule should be %s, received %s", s, decoded)
+			}
+			start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			if wanted, received := s.NextN(start, 5), decoded.NextN(start, 5); !slices.EqualFunc(wanted, received, time.Time.Equal) {
+				t.Errorf("decoded schedule should be due at %v, received %v", wanted, received)
+			}
+		})
+	}
+
+	var s Schedule
+	if err := s.UnmarshalText([]byte("61 * * * *")); err == nil {
+		t.Errorf("invalid expression should return an error")
+	}
+	if err := s.UnmarshalText([]byte("DAYS=any 0 0 1 * MON")); err == nil {
+		t.Errorf("invalid day matching should return an error")
+	}
+
+	// calendars which cannot be looked up by name cannot be parsed from the expression again
+	for _, c := range []Calendar{NewCalendar(""), NewCalendar("marshal-unregistered"), NewCalendar("marshal-holidays", WithExcludedWeekdays(time.Sunday))} {
+		s, err := NewSchedule("0 0 * * *", WithCalendar(c, CalendarSkip))
+		if err != nil {
+			t.Fatalf("cannot create schedule: %v", err)
+		}
+		if _, err = s.MarshalText(); err == nil {
+			t.Errorf("schedule with calendar %q which is not registered should return an error", c.Name())
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package cron
+
+const (
+	SeverityError   Severity = iota // the expression cannot be parsed
+	SeverityWarning                 // the expression can be parsed, but probably does not do what was intended
+)
+
+var severityStrings = []string{"error", "warning"}
+
+type Severity int
+
+func (s Severity) String() string {
+	return severityStrings[s]
+}
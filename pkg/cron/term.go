@@ -0,0 +1,86 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parseTerm parses a single term of an element expression for position p.
+// A term is a wildcard (*), a single value (5) or a range (1-5), optionally followed by a step (*/5, 1-30/5, 10/5).
+// A step applies to the values from the start of the term; a single value followed by a step runs to the end of the position.
+func parseTerm(s string, p position) (term, error) {
+	var (
+		t   term
+		err error
+	)
+
+	t.step = 1
+	value, step, hasStep := strings.Cut(s, "/")
+	if hasStep {
+		if t.step, err = strconv.Atoi(step); err != nil {
+			return t, newParseError(p, ReasonBadStep, "invalid step %s in %s", step, p.String())
+		}
+		if t.step < 1 {
+			return t, newParseError(p, ReasonBadStep, "invalid step %d in %s", t.step, p.String())
+		}
+	}
+
+	if value == "*" {
+		t.start, t.end = p.min(), p.max()
+		return t, nil
+	}
+
+	from, to, isRange := strings.Cut(value, "-")
+	if t.start, err = strconv.Atoi(from); err != nil {
+		return t, newParseError(p, ReasonUnknownLiteral, "invalid value %s in %s", from, p.String())
+	}
+
+	switch {
+	case isRange:
+		if t.end, err = strconv.Atoi(to); err != nil {
+			return t, newParseError(p, ReasonUnknownLiteral, "invalid value %s in %s", to, p.String())
+		}
+		if t.start > t.end {
+			return t, newParseError(p, ReasonUnorderedList, "invalid order of values in %s", p.String())
+		}
+	case hasStep:
+		t.end = p.max()
+	default:
+		t.end = t.start
+	}
+	return t, nil
+}
+
+// term defines the values between start and end (inclusive), separated by step.
+type term struct {
+	start int
+	end   int
+	step  int
+}
+
+// contains checks if v is one of the values of the term.
+func (t term) contains(v int) bool {
+	return v >= t.start && v <= t.end && (v-t.start)%t.step == 0
+}
+
+// next returns the first value of the term which is equal to or greater than v.
+// Returns false if no such value exists.
+func (t term) next(v int) (int, bool) {
+	if v <= t.start {
+		return t.start, true
+	}
+
+	n := t.start + (v-t.start+t.step-1)/t.step*t.step
+	return n, n <= t.end
+}
+
+// prev returns the last value of the term which is equal to or less than v.
+// Returns false if no such value exists.
+func (t term) prev(v int) (int, bool) {
+	if v < t.start {
+		return 0, false
+	}
+
+	n := t.start + (min(v, t.end)-t.start)/t.step*t.step
+	return n, true
+}
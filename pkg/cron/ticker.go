@@ -7,25 +7,43 @@ import (
 	"time"
 )
 
-const tickerInterval = 1 * time.Second
+const (
+	tickerInterval = 1 * time.Second
 
-// NewTicker returns a cron Ticker based on the input schedule s.
-// Ticket will send the time to channel chTrigger when the schedule is due.
-func NewTicker(s Schedule, chTrigger chan<- time.Time) *Ticker {
+	// tickerMaxSleep limits how long a ticker in timer mode sleeps before checking the wall clock again.
+	// Timers run on the monotonic clock, so this makes sure wall clock jumps are picked up in time.
+	tickerMaxSleep = 1 * time.Minute
+)
+
+// NewTicker returns a cron Ticker based on the input timetable s, such as a Schedule, Interval or Once.
+// Ticket will send the time to channel chTrigger when the timetable is due.
+func NewTicker(s Timetable, chTrigger chan<- time.Time, opts ...TickerOption) *Ticker {
 	t := &Ticker{
 		schedule:  s,
 		chTrigger: chTrigger,
+		mode:      TickerModePolling,
+		clock:     SystemClock(),
+	}
+
+	for _, opt := range opts {
+		opt(t)
 	}
 	return t
 }
 
 // Ticker creates a cron ticker that will send the current time to the output channel when the schedule is due.
 // It can be controlled using the start() and stop() functions, or by cancelling the parent context.
+// Times which are not sent within a second after they were due are missed, and handled by the misfire policy of the ticker.
 type Ticker struct {
 	tickerCancel context.CancelFunc
+	done         chan struct{} // closed when the ticker stops
 
-	schedule  Schedule
-	chTrigger chan<- time.Time
+	schedule    Timetable
+	chTrigger   chan<- time.Time
+	mode        TickerMode
+	misfire     MisfirePolicy
+	lastTrigger time.Time
+	clock       Clock
 
 	mux sync.Mutex
 }
@@ -40,6 +58,14 @@ func (t *Ticker) IsRunning() bool {
 	return false
 }
 
+// Done returns a channel which is closed when the ticker stops, because it was stopped or its timetable will not be due anymore.
+// The channel is replaced each time the ticker starts, and is nil before the first start.
+func (t *Ticker) Done() <-chan struct{} {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.done
+}
+
 // Start the cron ticker.
 func (t *Ticker) Start(ctx context.Context) error {
 	if t.IsRunning() {
@@ -51,7 +77,16 @@ func (t *Ticker) Start(ctx context.Context) error {
 
 	var tickerCtx context.Context
 	tickerCtx, t.tickerCancel = context.WithCancel(ctx)
-	go t.tick(tickerCtx, t.schedule, t.chTrigger)
+	t.done = make(chan struct{})
+	go func(done chan struct{}) {
+		defer close(done)
+		switch t.mode {
+		case TickerModeTimer:
+			t.wait(tickerCtx, t.schedule, t.chTrigger)
+		default:
+			t.tick(tickerCtx, t.schedule, t.chTrigger)
+		}
+	}(t.done)
 
 	return nil
 }
@@ -68,6 +103,19 @@ func (t *Ticker) Stop() error {
 	return nil
 }
 
+// fireMissed sends the times at which s was due after last and at or before now to chTrigger, according to the misfire policy.
+// Returns false if ctx is done before all times are sent.
+func (t *Ticker) fireMissed(ctx context.Context, s Timetable, chTrigger chan<- time.Time, last time.Time, now time.Time) bool {
+	for _, missed := range t.misfire.Missed(s, last, now) {
+		select {
+		case <-ctx.Done():
+			return false
+		case chTrigger <- missed:
+		}
+	}
+	return true
+}
+
 func (t *Ticker) resetCancelFunc() {
 	t.mux.Lock()
 	defer t.mux.Unlock()
@@ -75,23 +123,88 @@ func (t *Ticker) resetCancelFunc() {
 }
 
 // tick is the function called by start to initiate the goroutine
-func (t *Ticker) tick(ctx context.Context, s Schedule, chTrigger chan<- time.Time) {
-	ticker := time.NewTicker(tickerInterval)
-	var exit bool
-	for {
-		if exit {
-			break
-		}
+func (t *Ticker) tick(ctx context.Context, s Timetable, chTrigger chan<- time.Time) {
+	defer t.resetCancelFunc()
 
+	now := t.clock.Now()
+	timer := t.clock.NewTimer(untilTick(now))
+	defer timer.Stop()
+
+	last := t.lastTrigger
+	if last.IsZero() {
+		last = now.Truncate(time.Second)
+	}
+
+	for {
 		select {
 		case <-ctx.Done():
-			ticker.Stop()
-			exit = true
-		case trigger := <-ticker.C:
+			return
+		case trigger := <-timer.C():
+			// The seconds between the last tick and this one were missed, for example because the goroutine was starved.
+			if !t.fireMissed(ctx, s, chTrigger, last, trigger.Truncate(time.Second).Add(-time.Nanosecond)) {
+				return
+			}
+
 			if s.IsDue(trigger) {
 				chTrigger <- trigger
 			}
+			last = trigger.Truncate(time.Second)
+			timer.Reset(untilTick(t.clock.Now()))
+		}
+	}
+}
+
+// untilTick returns the duration from now until the next whole tickerInterval.
+func untilTick(now time.Time) time.Duration {
+	return tickerInterval - now.Sub(now.Truncate(tickerInterval))
+}
+
+// wait is the function called by start to initiate the goroutine when the ticker runs in timer mode.
+// Instead of checking the schedule every second, it arms a single timer for the next time the schedule is due.
+// The schedule time is sent to chTrigger. When the timer fires late, the missed times are handled by the misfire policy.
+// Times before the last trigger are never sent, so the ticker does not fire twice when the wall clock moves backwards.
+func (t *Ticker) wait(ctx context.Context, s Timetable, chTrigger chan<- time.Time) {
+	defer t.resetCancelFunc()
+
+	timer := t.clock.NewTimer(tickerMaxSleep)
+	defer timer.Stop()
+
+	last := t.clock.Now().Round(0) // strip the monotonic clock reading, so comparisons use the wall clock
+	if !t.lastTrigger.IsZero() && !t.fireMissed(ctx, s, chTrigger, t.lastTrigger, last) {
+		return
+	}
+
+	for {
+		next, ok := s.Next(last)
+		if !ok { // the schedule will not be due anymore
+			return
+		}
+
+		now := t.clock.Now().Round(0)
+		for now.Before(next) {
+			timer.Reset(min(next.Sub(now), tickerMaxSleep))
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C():
+				now = t.clock.Now().Round(0)
+			}
+		}
+
+		// The time was missed, for example because the process was suspended or the wall clock jumped forward.
+		if now.Sub(next) > misfireThreshold {
+			if !t.fireMissed(ctx, s, chTrigger, last, now) {
+				return
+			}
+			last = now
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case chTrigger <- next:
+			last = now
 		}
 	}
-	t.resetCancelFunc()
 }
@@ -0,0 +1,14 @@
+package cron
+
+const (
+	TickerModePolling TickerMode = iota // ticker wakes up every second and checks if the schedule is due
+	TickerModeTimer                     // ticker sleeps until the next time the schedule is due
+)
+
+var tickerModeStrings = []string{"polling", "timer"}
+
+type TickerMode int
+
+func (m TickerMode) String() string {
+	return tickerModeStrings[m]
+}
@@ -0,0 +1,36 @@
+package cron
+
+import "time"
+
+type TickerOption func(*Ticker)
+
+// WithTickerMode sets the way the ticker waits for the schedule to become due.
+func WithTickerMode(mode TickerMode) TickerOption {
+	return func(t *Ticker) {
+		t.mode = mode
+	}
+}
+
+// WithLastTrigger sets the last time the ticker was triggered, for example before the process was restarted.
+// When the ticker starts, the times missed since the last trigger are handled by the misfire policy.
+func WithLastTrigger(last time.Time) TickerOption {
+	return func(t *Ticker) {
+		t.lastTrigger = last
+	}
+}
+
+// WithMisfirePolicy sets what happens with times that were not fired in time.
+// By default, missed times are ignored.
+func WithMisfirePolicy(policy MisfirePolicy) TickerOption {
+	return func(t *Ticker) {
+		t.misfire = policy
+	}
+}
+
+// WithClock sets the clock used by the ticker, for example a FakeClock in tests.
+// By default, the ticker uses the SystemClock.
+func WithClock(c Clock) TickerOption {
+	return func(t *Ticker) {
+		t.clock = c
+	}
+}
@@ -49,3 +49,149 @@ func TestNewTicker(t *testing.T) {
 		t.Errorf("ticker stop should return an error")
 	}
 }
+
+func TestNewTicker_TimerMode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(3)*time.Second)
+	defer cancel()
+
+	chTrigger := make(chan time.Time)
+	ticker := NewTicker(EverySecond(), chTrigger, WithTickerMode(TickerModeTimer))
+
+	if err := ticker.Start(ctx); err != nil {
+		t.Fatalf("ticker start should not return an error, received %v", err)
+	}
+
+	var previous time.Time
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("ticker should have been triggered")
+		case trigger := <-chTrigger:
+			if trigger.Nanosecond() != 0 {
+				t.Errorf("trigger should be aligned to the second, got %s", trigger)
+			}
+			if !trigger.After(previous) {
+				t.Errorf("trigger %s should be after %s", trigger, previous)
+			}
+			previous = trigger
+		}
+	}
+
+	if err := ticker.Stop(); err != nil {
+		t.Errorf("ticker stop should not return an error, received %v", err)
+	}
+}
+
+func TestNewTicker_Misfire(t *testing.T) {
+	for _, mode := range []TickerMode{TickerModePolling, TickerModeTimer} {
+		t.Run(mode.String(), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(3)*time.Second)
+			defer cancel()
+
+			start := time.Now()
+			last := start.Add(-10 * time.Second).Truncate(time.Second)
+
+			chTrigger := make(chan time.Time)
+			ticker := NewTicker(EverySecond(), chTrigger, WithTickerMode(mode), WithMisfirePolicy(FireAll(3, 0)), WithLastTrigger(last))
+			if err := ticker.Start(ctx); err != nil {
+				t.Fatalf("ticker start should not return an error, received %v", err)
+			}
+			defer ticker.Stop()
+
+			var previous time.Time
+			for i := 0; i < 3; i++ {
+				select {
+				case <-ctx.Done():
+					t.Fatalf("ticker should have fired the missed times")
+				case trigger := <-chTrigger:
+					if !trigger.Before(start) || !trigger.After(last) {
+						t.Errorf("trigger %s should have been missed between %s and %s", trigger, last, start)
+					}
+					if !trigger.After(previous) {
+						t.Errorf("trigger %s should be after %s", trigger, previous)
+					}
+					previous = trigger
+				}
+			}
+		})
+	}
+}
+
+func TestNewTicker_FakeClock(t *testing.T) {
+	tests := []struct {
+		mode TickerMode
+		step time.Duration
+	}{
+		{mode: TickerModePolling, step: time.Second},
+		{mode: TickerModeTimer, step: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			start := time.Date(2026, 3, 1, 0, 0, 0, 500000000, time.Local)
+			clock := NewFakeClock(start)
+
+			chTrigger := make(chan time.Time, 48)
+			ticker := NewTicker(mustParse(t, "0 * * * *"), chTrigger, WithTickerMode(tt.mode), WithClock(clock))
+			if err := ticker.Start(ctx); err != nil {
+				t.Fatalf("ticker start should not return an error, received %v", err)
+			}
+			defer ticker.Stop()
+
+			// simulate a whole day
+			for clock.Now().Before(start.Add(24 * time.Hour)) {
+				clock.WaitForTimers(1)
+				clock.Advance(tt.step)
+			}
+			clock.WaitForTimers(1)
+
+			if len(chTrigger) != 24 {
+				t.Fatalf("ticker should have fired 24 times, received %d", len(chTrigger))
+			}
+			for i := 1; i <= 24; i++ {
+				trigger := <-chTrigger
+				if want := start.Truncate(time.Hour).Add(time.Duration(i) * time.Hour); !trigger.Equal(want) {
+					t.Errorf("trigger %d should be %s, received %s", i, want, trigger)
+				}
+			}
+		})
+	}
+}
+
+func TestTicker_Done(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	chTrigger := make(chan time.Time, 1)
+	ticker := NewTicker(NewOnce(start.Add(time.Minute)), chTrigger, WithTickerMode(TickerModeTimer), WithClock(clock))
+	if err := ticker.Start(ctx); err != nil {
+		t.Fatalf("ticker start should not return an error, received %v", err)
+	}
+
+	clock.WaitForTimers(1)
+	clock.Advance(time.Minute)
+	select {
+	case <-ticker.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("ticker should stop when its timetable will not be due anymore")
+	}
+	if len(chTrigger) != 1 || ticker.IsRunning() {
+		t.Errorf("stopped ticker should have fired once, received %d triggers", len(chTrigger))
+	}
+
+	// a new start replaces the channel of the stopped ticker
+	if err := ticker.Start(ctx); err != nil {
+		t.Fatalf("ticker start should not return an error, received %v", err)
+	}
+	select {
+	case <-ticker.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("restarted ticker should stop when its timetable will not be due anymore")
+	}
+}
@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	everyPrefix = "@every "
+	atPrefix    = "@at "
+)
+
+// Timetable defines when something is due.
+// It is implemented by Schedule for cron expressions, by Interval for @every expressions, by Once for @at expressions
+// and by Composite for combinations of other timetables.
+type Timetable interface {
+	// IsDue checks if the timetable is due at t.
+	IsDue(t time.Time) bool
+	// Next returns the first time after the input time at which the timetable is due.
+	Next(after time.Time) (time.Time, bool)
+	// Prev returns the last time before the input time at which the timetable was due.
+	Prev(before time.Time) (time.Time, bool)
+	// String returns the expression of the timetable, which can be parsed again using Parse.
+	String() string
+}
+
+// Parse returns the Timetable for the input expression.
+// Expressions starting with @every return an Interval, expressions starting with @at return a Once.
+// Expressions such as union(a; b), intersect(a; b) and except(base; blackout) return a Composite.
+// All other expressions return a Schedule, to which the options are applied.
+// Returns an error if the expression cannot be parsed.
+func Parse(expression string, opts ...ScheduleOption) (Timetable, error) {
+	expression = strings.TrimSpace(expression)
+
+	if c, ok, err := parseComposite(expression, opts...); ok {
+		return c, err
+	}
+
+	switch {
+	case strings.HasPrefix(expression, everyPrefix):
+		return parseInterval(strings.TrimPrefix(expression, everyPrefix))
+	case strings.HasPrefix(expression, atPrefix):
+		return parseOnce(strings.TrimPrefix(expression, atPrefix))
+	default:
+		return NewSchedule(expression, opts...)
+	}
+}
+
+// parseInterval parses the expression of an interval, without the @every prefix.
+// The expression is a duration, optionally followed by "from" and the anchor time in RFC3339 format.
+func parseInterval(expression string) (Interval, error) {
+	every, anchor, hasAnchor := strings.Cut(strings.TrimSpace(expression), " from ")
+
+	d, err := time.ParseDuration(strings.TrimSpace(every))
+	if err != nil {
+		return Interval{}, fmt.Errorf("invalid interval %s: %w", every, err)
+	}
+
+	var a time.Time
+	if hasAnchor {
+		if a, err = time.Parse(time.RFC3339, strings.TrimSpace(anchor)); err != nil {
+			return Interval{}, fmt.Errorf("invalid interval anchor %s: %w", anchor, err)
+		}
+	}
+	return NewInterval(d, a)
+}
+
+// parseOnce parses the expression of a single run, without the @at prefix.
+func parseOnce(expression string) (Once, error) {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(expression))
+	if err != nil {
+		return Once{}, fmt.Errorf("invalid time %s: %w", expression, err)
+	}
+	return NewOnce(t), nil
+}
@@ -0,0 +1,49 @@
+package cron
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	var tests = []struct {
+		expression string
+		success    bool
+		wanted     string
+	}{
+		{"* * * * *", true, "* * * * *"},
+		{"@daily", true, "0 0 * * *"},
+		{"@every 90s", true, "@every 1m30s"},
+		{"@every 1h30m", true, "@every 1h30m0s"},
+		{"@every 1h from 2025-01-01T12:00:00Z", true, "@every 1h0m0s from 2025-01-01T12:00:00Z"},
+		{"@at 2026-12-31T23:00:00Z", true, "@at 2026-12-31T23:00:00Z"},
+		{"@at 2026-12-31T23:00:00+01:00", true, "@at 2026-12-31T23:00:00+01:00"},
+
+		{"@every", false, ""},
+		{"@every 90", false, ""},
+		{"@every 500ms", false, ""},
+		{"@every -1m", false, ""},
+		{"@every 1h from tomorrow", false, ""},
+		{"@at 2026-12-31", false, ""},
+		{"@at", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			tt2, err := Parse(tt.expression)
+			if (err == nil) != tt.success {
+				t.Fatalf("unexpected result for %s with error: %v", tt.expression, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := tt2.String(); got != tt.wanted {
+				t.Errorf("String() = %s, wanted %s", got, tt.wanted)
+			}
+
+			if _, err = Parse(tt2.String()); err != nil {
+				t.Errorf("cannot parse %s again: %v", tt2.String(), err)
+			}
+		})
+	}
+}
@@ -1,6 +1,13 @@
 package job
 
-import "github.com/google/uuid"
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrRunLimitReached is returned by Catalog.ReserveRun when a job has no runs left.
+var ErrRunLimitReached = errors.New("run limit reached")
 
 type Catalog interface {
 	Add(job Job) error
@@ -9,11 +16,14 @@ type Catalog interface {
 	AllResults() map[uuid.UUID][]Result
 	Count() int
 	CountResults(uuid uuid.UUID) int
+	CountRuns(uuid uuid.UUID) int
 	Delete(uuid uuid.UUID) error
 	Get(uuid uuid.UUID) (Job, error)
 	GetNotSchedulable() []Job
 	GetSchedulable() []Job
 	GetResults(uuid uuid.UUID) ([]Result, error)
+	ReserveRun(uuid uuid.UUID) error
+	State(uuid uuid.UUID) (State, error)
 	Statistics() CatalogStatistics
 	Update(job Job) error
 }
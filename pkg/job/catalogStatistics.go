@@ -1,8 +1,9 @@
 package job
 
 type CatalogStatistics struct {
-	Count         int
-	EnabledCount  int
-	DisabledCount int
-	ResultCount   int
+	Count          int
+	EnabledCount   int
+	DisabledCount  int
+	CompletedCount int
+	ResultCount    int
 }
@@ -0,0 +1,193 @@
+package job
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/jantytgat/go-jobs/pkg/cron"
+	"github.com/jantytgat/go-jobs/pkg/task"
+)
+
+// definition is the serializable form of a job, used to encode jobs as JSON and YAML.
+// The schedule is encoded as its expression, the tasks as task definitions.
+type definition struct {
+	Uuid             uuid.UUID         `json:"uuid" yaml:"uuid"`
+	Name             string            `json:"name" yaml:"name"`
+	Schedule         string            `json:"schedule" yaml:"schedule"`
+	Enabled          bool              `json:"enabled" yaml:"enabled"`
+	LimitConcurrency bool              `json:"limitConcurrency" yaml:"limitConcurrency"`
+	MaxConcurrency   int               `json:"maxConcurrency" yaml:"maxConcurrency"`
+	Overlap          OverlapPolicy     `json:"overlap" yaml:"overlap"`
+	LimitRuns        bool              `json:"limitRuns" yaml:"limitRuns"`
+	MaxRuns          int               `json:"maxRuns" yaml:"maxRuns"`
+	Misfire          misfireDefinition `json:"misfire" yaml:"misfire"`
+	Tasks            []task.Definition `json:"tasks" yaml:"tasks"`
+}
+
+type misfireDefinition struct {
+	Mode    cron.MisfireMode `json:"mode" yaml:"mode"`
+	MaxRuns int              `json:"maxRuns,omitempty" yaml:"maxRuns,omitempty"`
+	Grace   string           `json:"grace,omitempty" yaml:"grace,omitempty"`
+}
+
+// newDefinition returns the definition of job j.
+func newDefinition(j Job) (definition, error) {
+	d := definition{
+		Uuid:             j.Uuid,
+		Name:             j.Name,
+		Enabled:          j.Enabled,
+		LimitConcurrency: j.LimitConcurrency,
+		MaxConcurrency:   j.MaxConcurrency,
+		Overlap:          j.OverlapPolicy,
+		LimitRuns:        j.LimitRuns,
+		MaxRuns:          j.MaxRuns,
+		Misfire: misfireDefinition{
+			Mode:    j.MisfirePolicy.Mode,
+			MaxRuns: j.MisfirePolicy.MaxRuns,
+		},
+		Tasks: make([]task.Definition, 0, len(j.Tasks)),
+	}
+
+	if j.Schedule != nil {
+		d.Schedule = j.Schedule.String()
+		if m, ok := j.Schedule.(encoding.TextMarshaler); ok {
+			text, err := m.MarshalText()
+			if err != nil {
+				return definition{}, fmt.Errorf("job %s: %w", j.Name, err)
+			}
+			d.Schedule = string(text)
+		}
+	}
+	if j.MisfirePolicy.Grace > 0 {
+		d.Misfire.Grace = j.MisfirePolicy.Grace.String()
+	}
+
+	for _, t := range j.Tasks {
+		td, err := task.NewDefinition(t)
+		if err != nil {
+			return definition{}, fmt.Errorf("job %s: %w", j.Name, err)
+		}
+		d.Tasks = append(d.Tasks, td)
+	}
+	return d, nil
+}
+
+// defaultDefinition returns the definition to decode into, with the defaults of New for fields which are omitted.
+func defaultDefinition() definition {
+	return definition{
+		Enabled:          true,
+		LimitConcurrency: true,
+		MaxConcurrency:   1,
+	}
+}
+
+// definitionNamespace is the namespace for the uuids of jobs which are defined without a uuid.
+var definitionNamespace = uuid.MustParse("8f1c3b52-5d0e-4c55-9b8e-2f7c6a4d9e01")
+
+// job returns the job defined by d.
+// A job without a uuid gets a uuid derived from its name, so it keeps the same uuid when it is decoded again.
+// The schedule is parsed with the uuid of the job as seed, so H tokens resolve to the same values for the same job.
+func (d definition) job() (Job, error) {
+	if d.Uuid == uuid.Nil {
+		d.Uuid = uuid.NewSHA1(definitionNamespace, []byte(d.Name))
+	}
+
+	j := Job{
+		Uuid:             d.Uuid,
+		Name:             d.Name,
+		Enabled:          d.Enabled,
+		LimitConcurrency: d.LimitConcurrency,
+		MaxConcurrency:   d.MaxConcurrency,
+		OverlapPolicy:    d.Overlap,
+		LimitRuns:        d.LimitRuns,
+		MaxRuns:          d.MaxRuns,
+		MisfirePolicy: cron.MisfirePolicy{
+			Mode:    d.Misfire.Mode,
+			MaxRuns: d.Misfire.MaxRuns,
+		},
+		Tasks: make([]task.Task, 0, len(d.Tasks)),
+	}
+
+	var err error
+	if d.Schedule != "" {
+		if j.Schedule, err = cron.Parse(d.Schedule, cron.WithSeed(d.Uuid.String())); err != nil {
+			return Job{}, fmt.Errorf("job %s: invalid schedule: %w", d.Name, err)
+		}
+	}
+	if d.Misfire.Grace != "" {
+		if j.MisfirePolicy.Grace, err = time.ParseDuration(d.Misfire.Grace); err != nil {
+			return Job{}, fmt.Errorf("job %s: invalid misfire grace: %w", d.Name, err)
+		}
+	}
+
+	for _, td := range d.Tasks {
+		j.Tasks = append(j.Tasks, td.Task)
+	}
+	return j, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// The types of the tasks of the job must be registered using task.RegisterType.
+func (j Job) MarshalJSON() ([]byte, error) {
+	d, err := newDefinition(j)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(d)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Omitted fields get the defaults of New, a missing uuid is derived from the name of the job,
+// and the schedule is parsed with the uuid of the job as seed. Unknown fields are rejected.
+func (j *Job) UnmarshalJSON(data []byte) error {
+	d := defaultDefinition()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&d); err != nil {
+		return err
+	}
+
+	decoded, err := d.job()
+	if err != nil {
+		return err
+	}
+	*j = decoded
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// The types of the tasks of the job must be registered using task.RegisterType.
+func (j Job) MarshalYAML() (any, error) {
+	return newDefinition(j)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// Omitted fields get the defaults of New, a missing uuid is derived from the name of the job,
+// and the schedule is parsed with the uuid of the job as seed. Unknown fields are rejected.
+func (j *Job) UnmarshalYAML(node *yaml.Node) error {
+	// yaml.Node.Decode does not reject unknown fields, so the node is decoded again with a strict decoder
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+
+	d := defaultDefinition()
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err = dec.Decode(&d); err != nil {
+		return err
+	}
+
+	decoded, err := d.job()
+	if err != nil {
+		return err
+	}
+	*j = decoded
+	return nil
+}
@@ -0,0 +1,220 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/jantytgat/go-jobs/pkg/cron"
+	"github.com/jantytgat/go-jobs/pkg/task"
+)
+
+type definitionTestTask struct {
+	Message string
+}
+
+func (t definitionTestTask) Name() string                         { return "definitionTestTask" }
+func (t definitionTestTask) DefaultHandler() task.Handler         { return task.Handler{} }
+func (t definitionTestTask) Handler(_ time.Duration) task.Handler { return task.Handler{} }
+func (t definitionTestTask) DefaultHandlerPool(_ context.Context) *task.HandlerPool {
+	return nil
+}
+func (t definitionTestTask) HandlerPool(_ context.Context, _ time.Duration) *task.HandlerPool {
+	return nil
+}
+
+func init() {
+	_ = task.RegisterType("job-definition-test", definitionTestTask{})
+}
+
+func TestJob_Encoding(t *testing.T) {
+	id := uuid.New()
+	schedule, err := cron.Parse("H H * * *", cron.WithSeed(id.String()))
+	if err != nil {
+		t.Fatalf("cannot parse schedule: %v", err)
+	}
+	holidays := cron.NewCalendar("definition-holidays", cron.WithExcludedDates(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	if err = cron.RegisterCalendar(holidays); err != nil {
+		t.Fatalf("cannot register calendar: %v", err)
+	}
+
+	var tests = []struct {
+		name string
+		job  Job
+	}{
+		{
+			name: "defaults",
+			job:  New(uuid.New(), "defaults", mustParse(t, "*/5 * * * *"), []task.Task{definitionTestTask{Message: "hello"}}),
+		},
+		{
+			name: "options",
+			job: New(uuid.New(), "options", mustParse(t, "union(@every 90m; 0 12 * * MON)"),
+				[]task.Task{definitionTestTask{Message: "first"}, definitionTestTask{Message: "second"}},
+				WithDisabled(), WithConcurrencyLimit(3), WithOverlapPolicy(OverlapQueue), WithRunLimit(10), WithMisfirePolicy(cron.FireAll(5, time.Hour))),
+		},
+		{
+			name: "seeded",
+			job:  New(id, "seeded", schedule, nil),
+		},
+		{
+			name: "strict days",
+			job:  New(uuid.New(), "strict days", mustParse(t, "0 0 1 * MON", cron.WithStrictDayMatching()), nil),
+		},
+		{
+			name: "calendar",
+			job:  New(uuid.New(), "calendar", mustParse(t, "0 0 * * *", cron.WithCalendar(holidays, cron.CalendarNext)), nil),
+		},
+	}
+
+	codecs := []struct {
+		name      string
+		marshal   func(any) ([]byte, error)
+		unmarshal func([]byte, any) error
+	}{
+		{name: "json", marshal: json.Marshal, unmarshal: json.Unmarshal},
+		{name: "yaml", marshal: yaml.Marshal, unmarshal: yaml.Unmarshal},
+	}
+
+	for _, codec := range codecs {
+		for _, tt := range tests {
+			t.Run(codec.name+"/"+tt.name, func(t *testing.T) {
+				data, err := codec.marshal(tt.job)
+				if err != nil {
+					t.Fatalf("cannot marshal job: %v", err)
+				}
+
+				var decoded Job
+				if err = codec.unmarshal(data, &decoded); err != nil {
+					t.Fatalf("cannot unmarshal %s: %v", data, err)
+				}
+
+				if decoded.Schedule.String() != tt.job.Schedule.String() {
+					t.Errorf("schedule should be %s, received %s", tt.job.Schedule, decoded.Schedule)
+				}
+				start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+				if wanted, received := nextN(tt.job.Schedule, start, 5), nextN(decoded.Schedule, start, 5); !reflect.DeepEqual(wanted, received) {
+					t.Errorf("schedule should be due at %v, received %v", wanted, received)
+				}
+
+				decoded.Schedule, tt.job.Schedule = nil, nil
+				if len(tt.job.Tasks) == 0 {
+					tt.job.Tasks = []task.Task{}
+				}
+				if !reflect.DeepEqual(decoded, tt.job) {
+					t.Errorf("decoded job should be %+v, received %+v", tt.job, decoded)
+				}
+			})
+		}
+	}
+}
+
+func TestJob_UnmarshalDefaults(t *testing.T) {
+	var j Job
+	if err := yaml.Unmarshal([]byte("name: minimal\nschedule: '@hourly'\n"), &j); err != nil {
+		t.Fatalf("cannot unmarshal job: %v", err)
+	}
+
+	if !j.Enabled || !j.LimitConcurrency || j.MaxConcurrency != 1 || j.LimitRuns {
+		t.Errorf("omitted fields should have the defaults of New, received %+v", j)
+	}
+}
+
+func TestJob_UnmarshalInvalid(t *testing.T) {
+	var tests = []struct {
+		name string
+		data string
+	}{
+		{name: "schedule", data: `{"name":"invalid","schedule":"61 * * * *"}`},
+		{name: "task kind", data: `{"name":"invalid","tasks":[{"kind":"unknown"}]}`},
+		{name: "misfire mode", data: `{"name":"invalid","misfire":{"mode":"sometimes"}}`},
+		{name: "misfire grace", data: `{"name":"invalid","misfire":{"mode":"fire-all","grace":"soon"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var j Job
+			if err := json.Unmarshal([]byte(tt.data), &j); err == nil {
+				t.Errorf("%s should return an error", tt.data)
+			}
+		})
+	}
+}
+
+func TestJob_UnmarshalUnknownField(t *testing.T) {
+	var tests = []struct {
+		name      string
+		data      string
+		unmarshal func([]byte, any) error
+	}{
+		{name: "json", data: `{"name":"invalid","schedule":"@hourly","maxConcurency":2}`, unmarshal: json.Unmarshal},
+		{name: "json misfire", data: `{"name":"invalid","schedule":"@hourly","misfire":{"mode":"fire-all","retries":1}}`, unmarshal: json.Unmarshal},
+		{name: "yaml", data: "name: invalid\nschedule: '@hourly'\nmaxConcurency: 2\n", unmarshal: yaml.Unmarshal},
+		{name: "yaml case", data: "name: invalid\nschedule: '@hourly'\nlimitruns: true\n", unmarshal: yaml.Unmarshal},
+		{name: "yaml misfire", data: "name: invalid\nschedule: '@hourly'\nmisfire:\n  mode: fire-all\n  retries: 1\n", unmarshal: yaml.Unmarshal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var j Job
+			if err := tt.unmarshal([]byte(tt.data), &j); err == nil {
+				t.Errorf("%s should return an error for the unknown field", tt.data)
+			}
+		})
+	}
+}
+
+func TestJob_MarshalUnregisteredCalendar(t *testing.T) {
+	schedule, err := cron.Parse("0 0 * * *", cron.WithCalendar(cron.NewCalendar("definition-unregistered"), cron.CalendarSkip))
+	if err != nil {
+		t.Fatalf("cannot parse schedule: %v", err)
+	}
+
+	j := New(uuid.New(), "unregistered", schedule, nil)
+	if _, err = json.Marshal(j); err == nil {
+		t.Errorf("job with a schedule with an unregistered calendar should return an error")
+	}
+}
+
+func TestJob_MarshalUnregisteredTask(t *testing.T) {
+	j := New(uuid.New(), "unregistered", cron.EverySecond(), []task.Task{&definitionTestTask{}})
+	if _, err := json.Marshal(j); err == nil {
+		t.Errorf("job with an unregistered task type should return an error")
+	}
+}
+
+// nextN returns the next n times after start at which tt is due.
+func nextN(tt cron.Timetable, start time.Time, n int) []time.Time {
+	var times []time.Time
+	for len(times) < n {
+		next, ok := tt.Next(start)
+		if !ok {
+			break
+		}
+		times = append(times, next.UTC())
+		start = next
+	}
+	return times
+}
+
+func TestJob_UnmarshalUuid(t *testing.T) {
+	var first, second Job
+	if err := json.Unmarshal([]byte(`{"name":"backup","schedule":"H 2 * * *"}`), &first); err != nil {
+		t.Fatalf("cannot unmarshal job: %v", err)
+	}
+	if err := yaml.Unmarshal([]byte("name: backup\nschedule: H 2 * * *\n"), &second); err != nil {
+		t.Fatalf("cannot unmarshal job: %v", err)
+	}
+
+	if first.Uuid == uuid.Nil || first.Uuid != second.Uuid {
+		t.Errorf("jobs without a uuid should get the same uuid derived from their name, received %s and %s", first.Uuid, second.Uuid)
+	}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if a, b := nextN(first.Schedule, start, 3), nextN(second.Schedule, start, 3); !reflect.DeepEqual(a, b) {
+		t.Errorf("schedules should be due at the same times, received %v and %v", a, b)
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/jantytgat/go-jobs/pkg/task"
 )
 
-func New(uuid uuid.UUID, name string, schedule cron.Schedule, tasks []task.Task, opts ...Option) Job {
+func New(uuid uuid.UUID, name string, schedule cron.Timetable, tasks []task.Task, opts ...Option) Job {
 	j := Job{
 		Uuid:             uuid,
 		Name:             name,
@@ -32,12 +32,14 @@ func New(uuid uuid.UUID, name string, schedule cron.Schedule, tasks []task.Task,
 type Job struct {
 	Uuid             uuid.UUID
 	Name             string
-	Schedule         cron.Schedule
+	Schedule         cron.Timetable
 	Enabled          bool
 	LimitConcurrency bool
 	MaxConcurrency   int
+	OverlapPolicy    OverlapPolicy
 	LimitRuns        bool
 	MaxRuns          int
+	MisfirePolicy    cron.MisfirePolicy
 	Tasks            []task.Task
 }
 
@@ -0,0 +1,322 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/jantytgat/go-jobs/pkg/cron"
+)
+
+const defaultLoaderInterval = 5 * time.Second
+
+// NewLoader returns a Loader which loads the job files in directory dir into catalog c.
+func NewLoader(dir string, c Catalog, opts ...LoaderOption) *Loader {
+	l := &Loader{
+		dir:      dir,
+		catalog:  c,
+		interval: defaultLoaderInterval,
+		clock:    cron.SystemClock(),
+		jobs:     make(map[uuid.UUID]loadedJob),
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Loader loads job definitions from the .json, .yaml and .yml files in a directory into a catalog.
+// A file contains a single job or a list of jobs, and YAML files may contain multiple documents.
+// Jobs are encoded as described by Job.MarshalJSON, with tasks as task definitions.
+//
+// Every load applies the differences with the previous load to the catalog: new jobs are added,
+// changed jobs are updated and jobs which are no longer defined are deleted.
+// Jobs which already exist in the catalog with the same uuid, for example because they were added in code, are updated.
+// The jobs of a file which cannot be loaded are kept as they were, until the file is fixed or removed.
+type Loader struct {
+	dir      string
+	catalog  Catalog
+	interval time.Duration
+	clock    cron.Clock
+	jobs     map[uuid.UUID]loadedJob // jobs applied to the catalog by the previous load
+	mux      sync.Mutex
+}
+
+type loadedJob struct {
+	file        string
+	job         Job
+	fingerprint string
+}
+
+// Load reads the job files in the directory and applies the differences with the previous load to the catalog.
+func (l *Loader) Load() LoadResult {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	var result LoadResult
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		result.Errors = append(result.Errors, &FileError{File: l.dir, Err: err})
+		return result
+	}
+
+	var order []uuid.UUID
+	loaded := make(map[uuid.UUID]loadedJob)
+	failed := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() || !isJobFile(entry.Name()) {
+			continue
+		}
+
+		file := filepath.Join(l.dir, entry.Name())
+		jobs, err := l.read(file, loaded)
+		if err != nil {
+			result.Errors = append(result.Errors, &FileError{File: file, Err: err})
+			failed[file] = true
+			continue
+		}
+
+		for _, j := range jobs {
+			loaded[j.job.Uuid] = j
+			order = append(order, j.job.Uuid)
+		}
+	}
+
+	// keep the jobs of files which cannot be loaded
+	for id, previous := range l.jobs {
+		if _, ok := loaded[id]; !ok && failed[previous.file] {
+			loaded[id] = previous
+			order = append(order, id)
+		}
+	}
+
+	applied := make(map[uuid.UUID]loadedJob, len(loaded))
+	for _, id := range order {
+		current := loaded[id]
+		previous, known := l.jobs[id]
+		if known && previous.fingerprint == current.fingerprint {
+			applied[id] = current
+			continue
+		}
+
+		if _, err = l.catalog.Get(id); err == nil {
+			err = l.catalog.Update(current.job)
+			if err == nil {
+				result.Updated = append(result.Updated, id)
+			}
+		} else {
+			err = l.catalog.Add(current.job)
+			if err == nil {
+				result.Added = append(result.Added, id)
+			}
+		}
+
+		if err != nil {
+			result.Errors = append(result.Errors, &FileError{File: current.file, Err: fmt.Errorf("job %s: %w", current.job.Name, err)})
+			if known {
+				applied[id] = previous
+			}
+			continue
+		}
+		applied[id] = current
+	}
+
+	for id, previous := range l.jobs {
+		if _, ok := applied[id]; ok {
+			continue
+		}
+
+		if err = l.catalog.Delete(id); err != nil {
+			result.Errors = append(result.Errors, &FileError{File: previous.file, Err: fmt.Errorf("job %s: %w", previous.job.Name, err)})
+			continue
+		}
+		result.Deleted = append(result.Deleted, id)
+	}
+
+	l.jobs = applied
+	return result
+}
+
+// Watch loads the directory immediately and then at every interval, until ctx is done.
+// Function fn is called with the result of every load which changed the catalog or reported errors.
+func (l *Loader) Watch(ctx context.Context, fn func(LoadResult)) {
+	timer := l.clock.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+			if result := l.Load(); result.Changed() || len(result.Errors) > 0 {
+				fn(result)
+			}
+			timer.Reset(l.interval)
+		}
+	}
+}
+
+// read returns the jobs defined in file, which must not be defined in other files that were already read.
+func (l *Loader) read(file string, loaded map[uuid.UUID]loadedJob) ([]loadedJob, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []Job
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		jobs, err = decodeJSONJobs(data)
+	default:
+		jobs, err = decodeYAMLJobs(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	read := make([]loadedJob, 0, len(jobs))
+	seen := make(map[uuid.UUID]bool, len(jobs))
+	for _, j := range jobs {
+		if err = validate(j); err != nil {
+			return nil, err
+		}
+		if other, ok := loaded[j.Uuid]; ok {
+			return nil, fmt.Errorf("job %s: uuid %s already defined in %s", j.Name, j.Uuid, other.file)
+		}
+		if seen[j.Uuid] {
+			return nil, fmt.Errorf("job %s: uuid %s defined more than once", j.Name, j.Uuid)
+		}
+		seen[j.Uuid] = true
+
+		fingerprint, err := json.Marshal(j)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", j.Name, err)
+		}
+		read = append(read, loadedJob{file: file, job: j, fingerprint: string(fingerprint)})
+	}
+	return read, nil
+}
+
+// LoadResult reports the changes a load applied to the catalog, and the files which could not be loaded.
+type LoadResult struct {
+	Added   []uuid.UUID
+	Updated []uuid.UUID
+	Deleted []uuid.UUID
+	Errors  []*FileError
+}
+
+// Changed returns true if the load added, updated or deleted jobs.
+func (r LoadResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Updated) > 0 || len(r.Deleted) > 0
+}
+
+// Err returns the errors of the load joined into a single error, or nil if there are none.
+func (r LoadResult) Err() error {
+	errs := make([]error, 0, len(r.Errors))
+	for _, err := range r.Errors {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// FileError is reported by the Loader for a job file which cannot be loaded.
+type FileError struct {
+	File string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return e.File + ": " + e.Err.Error()
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// decodeJSONJobs decodes a single job or a list of jobs.
+func decodeJSONJobs(data []byte) ([]Job, error) {
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		var jobs []Job
+		err := json.Unmarshal(data, &jobs)
+		return jobs, err
+	}
+
+	var j Job
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, err
+	}
+	return []Job{j}, nil
+}
+
+// decodeYAMLJobs decodes the documents in data, which each contain a single job or a list of jobs.
+func decodeYAMLJobs(data []byte) ([]Job, error) {
+	var jobs []Job
+	d := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var node yaml.Node
+		if err := d.Decode(&node); errors.Is(err, io.EOF) {
+			return jobs, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if len(node.Content) == 0 || node.Content[0].Tag == "!!null" { // empty document
+			continue
+		}
+
+		if node.Content[0].Kind == yaml.SequenceNode {
+			var list []Job
+			if err := node.Decode(&list); err != nil {
+				return nil, err
+			}
+			jobs = append(jobs, list...)
+			continue
+		}
+
+		var j Job
+		if err := node.Decode(&j); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+}
+
+// isJobFile checks if the name of a file has the extension of a job file.
+func isJobFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// validate checks that a job read from a file can be scheduled.
+func validate(j Job) error {
+	switch {
+	case j.Name == "":
+		return fmt.Errorf("job name required")
+	case j.Schedule == nil:
+		return fmt.Errorf("job %s: schedule required", j.Name)
+	case len(j.Tasks) == 0:
+		return fmt.Errorf("job %s: tasks required", j.Name)
+	case j.LimitConcurrency && j.MaxConcurrency < 1:
+		return fmt.Errorf("job %s: max concurrency must be at least 1", j.Name)
+	case j.LimitRuns && j.MaxRuns < 1:
+		return fmt.Errorf("job %s: max runs must be at least 1", j.Name)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,23 @@
+package job
+
+import (
+	"time"
+
+	"github.com/jantytgat/go-jobs/pkg/cron"
+)
+
+type LoaderOption func(*Loader)
+
+// WithLoaderInterval sets how often Watch loads the directory, which defaults to 5 seconds.
+func WithLoaderInterval(d time.Duration) LoaderOption {
+	return func(l *Loader) {
+		l.interval = d
+	}
+}
+
+// WithLoaderClock sets the clock used by Watch, for example a cron.FakeClock in tests.
+func WithLoaderClock(c cron.Clock) LoaderOption {
+	return func(l *Loader) {
+		l.clock = c
+	}
+}
@@ -0,0 +1,187 @@
+package job
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jantytgat/go-jobs/pkg/cron"
+)
+
+const (
+	loaderTestBackup = `
+name: backup
+schedule: "0 2 * * *"
+tasks:
+  - kind: job-definition-test
+    spec:
+      message: backup
+`
+	loaderTestReports = `[
+  {"name": "report-daily", "schedule": "@daily", "tasks": [{"kind": "job-definition-test", "spec": {"Message": "daily"}}]},
+  {"name": "report-weekly", "schedule": "@weekly", "tasks": [{"kind": "job-definition-test", "spec": {"Message": "weekly"}}]}
+]`
+)
+
+func writeJobFile(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatalf("cannot write %s: %v", name, err)
+	}
+}
+
+func catalogNames(c Catalog) []string {
+	var names []string
+	for _, j := range c.All() {
+		names = append(names, j.Name)
+	}
+	return names
+}
+
+func TestLoader_Load(t *testing.T) {
+	dir := t.TempDir()
+	writeJobFile(t, dir, "backup.yaml", loaderTestBackup)
+	writeJobFile(t, dir, "reports.json", loaderTestReports)
+	writeJobFile(t, dir, "README.md", "not a job file")
+
+	c := NewMemoryCatalog()
+	l := NewLoader(dir, c)
+
+	result := l.Load()
+	if err := result.Err(); err != nil {
+		t.Fatalf("load should not return errors, received %v", err)
+	}
+	if len(result.Added) != 3 || c.Count() != 3 {
+		t.Fatalf("load should add 3 jobs, received %d in a catalog of %v", len(result.Added), catalogNames(c))
+	}
+
+	// loading the same files again does not change the catalog
+	if result = l.Load(); result.Changed() {
+		t.Errorf("loading unchanged files should not change the catalog, received %+v", result)
+	}
+
+	writeJobFile(t, dir, "backup.yaml", strings.Replace(loaderTestBackup, "0 2 * * *", "0 3 * * *", 1))
+	if err := os.Remove(filepath.Join(dir, "reports.json")); err != nil {
+		t.Fatalf("cannot remove reports.json: %v", err)
+	}
+
+	result = l.Load()
+	if len(result.Added) != 0 || len(result.Updated) != 1 || len(result.Deleted) != 2 {
+		t.Fatalf("load should update 1 job and delete 2 jobs, received %+v", result)
+	}
+
+	j, err := c.Get(result.Updated[0])
+	if err != nil {
+		t.Fatalf("updated job should be in the catalog: %v", err)
+	}
+	if j.Schedule.String() != "0 3 * * *" {
+		t.Errorf("schedule should be updated to 0 3 * * *, received %s", j.Schedule)
+	}
+}
+
+func TestLoader_LoadErrors(t *testing.T) {
+	var tests = []struct {
+		name    string
+		content string
+		wanted  string
+	}{
+		{name: "syntax.json", content: `{"name": `, wanted: "unexpected end of JSON input"},
+		{name: "schedule.yaml", content: "name: invalid\nschedule: 61 * * * *\n", wanted: "invalid schedule"},
+		{name: "kind.yaml", content: "name: invalid\nschedule: '@daily'\ntasks: [{kind: ping}]\n", wanted: "unknown task kind"},
+		{name: "spec.yaml", content: "name: invalid\nschedule: '@daily'\ntasks: [{kind: job-definition-test, spec: {port: 80}}]\n", wanted: "invalid spec"},
+		{name: "name.yaml", content: "schedule: '@daily'\n", wanted: "job name required"},
+		{name: "tasks.yaml", content: "name: invalid\nschedule: '@daily'\n", wanted: "tasks required"},
+		{name: "duplicate.yaml", content: "[{name: other, schedule: '@daily', tasks: [{kind: job-definition-test}]}, {name: other, schedule: '@hourly', tasks: [{kind: job-definition-test}]}]", wanted: "defined more than once"},
+		{name: "other.yaml", content: loaderTestBackup, wanted: "already defined in"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeJobFile(t, dir, "backup.yaml", loaderTestBackup)
+			writeJobFile(t, dir, tt.name, tt.content)
+
+			c := NewMemoryCatalog()
+			result := NewLoader(dir, c).Load()
+			if len(result.Errors) != 1 {
+				t.Fatalf("load should return 1 error, received %v", result.Err())
+			}
+			if err := result.Errors[0]; err.File != filepath.Join(dir, tt.name) || !strings.Contains(err.Error(), tt.wanted) {
+				t.Errorf("error should report %s for %s, received %v", tt.wanted, tt.name, err)
+			}
+			if c.Count() != 1 {
+				t.Errorf("valid files should still be loaded, received %v", catalogNames(c))
+			}
+		})
+	}
+}
+
+func TestLoader_LoadKeepsFailedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeJobFile(t, dir, "backup.yaml", loaderTestBackup)
+
+	c := NewMemoryCatalog()
+	l := NewLoader(dir, c)
+	if result := l.Load(); len(result.Added) != 1 {
+		t.Fatalf("load should add 1 job, received %+v", result)
+	}
+
+	writeJobFile(t, dir, "backup.yaml", "name: backup\nschedule: [")
+	if result := l.Load(); len(result.Errors) != 1 || result.Changed() {
+		t.Fatalf("load should report the invalid file without changing the catalog, received %+v", result)
+	}
+	if c.Count() != 1 {
+		t.Errorf("job of the invalid file should be kept, received %v", catalogNames(c))
+	}
+}
+
+func TestLoader_LoadExistingJob(t *testing.T) {
+	dir := t.TempDir()
+	writeJobFile(t, dir, "backup.yaml", loaderTestBackup)
+
+	c := NewMemoryCatalog()
+	id := uuid.NewSHA1(definitionNamespace, []byte("backup"))
+	if err := c.Add(New(id, "backup", cron.EverySecond(), nil)); err != nil {
+		t.Fatalf("cannot add job: %v", err)
+	}
+
+	if result := NewLoader(dir, c).Load(); len(result.Updated) != 1 || result.Updated[0] != id {
+		t.Errorf("load should update the existing job, received %+v", result)
+	}
+}
+
+func TestLoader_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	writeJobFile(t, dir, "backup.yaml", loaderTestBackup)
+
+	clock := cron.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := NewMemoryCatalog()
+	l := NewLoader(dir, c, WithLoaderClock(clock), WithLoaderInterval(time.Minute))
+
+	chResults := make(chan LoadResult, 1)
+	go l.Watch(ctx, func(r LoadResult) { chResults <- r })
+
+	if result := <-chResults; len(result.Added) != 1 {
+		t.Fatalf("watch should add 1 job on start, received %+v", result)
+	}
+
+	writeJobFile(t, dir, "reports.json", loaderTestReports)
+	clock.WaitForTimers(1)
+	clock.Advance(time.Minute)
+
+	if result := <-chResults; len(result.Added) != 2 {
+		t.Fatalf("watch should add 2 jobs after the interval, received %+v", result)
+	}
+	if c.Count() != 3 {
+		t.Errorf("catalog should contain 3 jobs, received %v", catalogNames(c))
+	}
+}
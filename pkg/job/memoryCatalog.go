@@ -11,12 +11,16 @@ func NewMemoryCatalog() *MemoryCatalog {
 	return &MemoryCatalog{
 		jobs:    make(map[uuid.UUID]Job),
 		results: make(map[uuid.UUID][]Result),
+		runs:    make(map[uuid.UUID]int),
+		states:  make(map[uuid.UUID]State),
 	}
 }
 
 type MemoryCatalog struct {
 	jobs    map[uuid.UUID]Job
 	results map[uuid.UUID][]Result
+	runs    map[uuid.UUID]int   // runs reserved by job
+	states  map[uuid.UUID]State // state by job, jobs without a state are active
 
 	mux sync.Mutex
 }
@@ -30,9 +34,18 @@ func (c *MemoryCatalog) Add(job Job) error {
 	}
 
 	c.jobs[job.Uuid] = job
+	c.complete(job)
 	return nil
 }
 
+// complete moves the job to StateCompleted if it has no runs left; the caller must hold the lock.
+// A completed job stays completed, even if its run limit is raised.
+func (c *MemoryCatalog) complete(job Job) {
+	if job.LimitRuns && c.runs[job.Uuid] >= job.MaxRuns {
+		c.states[job.Uuid] = StateCompleted
+	}
+}
+
 func (c *MemoryCatalog) AddResult(result Result) {
 	c.mux.Lock()
 	defer c.mux.Unlock()
@@ -79,6 +92,14 @@ func (c *MemoryCatalog) CountResults(uuid uuid.UUID) int {
 	return len(c.results[uuid])
 }
 
+// CountRuns returns the number of runs reserved for the job, including runs which did not finish yet.
+func (c *MemoryCatalog) CountRuns(uuid uuid.UUID) int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.runs[uuid]
+}
+
 func (c *MemoryCatalog) Delete(uuid uuid.UUID) error {
 	c.mux.Lock()
 	defer c.mux.Unlock()
@@ -88,6 +109,8 @@ func (c *MemoryCatalog) Delete(uuid uuid.UUID) error {
 	}
 
 	delete(c.jobs, uuid)
+	delete(c.runs, uuid)
+	delete(c.states, uuid)
 	return nil
 }
 
@@ -102,27 +125,15 @@ func (c *MemoryCatalog) Get(uuid uuid.UUID) (Job, error) {
 	return c.jobs[uuid], nil
 }
 
+// GetNotSchedulable returns the jobs which completed their run limit.
 func (c *MemoryCatalog) GetNotSchedulable() []Job {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 
 	var jobs []Job
 	for id, job := range c.jobs {
-		if _, ok := c.results[id]; !ok {
-			continue
-		}
-
-		if !job.LimitRuns {
-			continue
-		}
-
-		if job.LimitRuns {
-			switch len(c.results[id]) == job.MaxRuns {
-			case true:
-				jobs = append(jobs, job)
-			default:
-				continue
-			}
+		if c.states[id] == StateCompleted {
+			jobs = append(jobs, job)
 		}
 	}
 	return jobs
@@ -139,31 +150,53 @@ func (c *MemoryCatalog) GetResults(uuid uuid.UUID) ([]Result, error) {
 	return c.results[uuid], nil
 }
 
+// GetSchedulable returns the jobs which are active, including disabled jobs.
 func (c *MemoryCatalog) GetSchedulable() []Job {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 
 	var jobs []Job
 	for id, job := range c.jobs {
-		if _, ok := c.results[id]; !ok {
+		if c.states[id] == StateActive {
 			jobs = append(jobs, job)
-			continue
 		}
+	}
+	return jobs
+}
 
-		if !job.LimitRuns {
-			jobs = append(jobs, job)
-			continue
-		}
+// ReserveRun reserves a run of the job before it is dispatched, so the run limit of the job is never exceeded.
+// The job moves to StateCompleted when its last run is reserved.
+// Returns ErrRunLimitReached if the job has no runs left.
+func (c *MemoryCatalog) ReserveRun(uuid uuid.UUID) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
 
-		if job.LimitRuns && len(c.results[id]) <= job.MaxRuns {
-			jobs = append(jobs, job)
-		}
+	job, ok := c.jobs[uuid]
+	if !ok {
+		return fmt.Errorf("job with uuid %s does not exist", uuid)
 	}
-	return jobs
+	if c.states[uuid] == StateCompleted {
+		return fmt.Errorf("job with uuid %s: %w", uuid, ErrRunLimitReached)
+	}
+
+	c.runs[uuid]++
+	c.complete(job)
+	return nil
+}
+
+// State returns the state of the job.
+func (c *MemoryCatalog) State(uuid uuid.UUID) (State, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if _, ok := c.jobs[uuid]; !ok {
+		return StateActive, fmt.Errorf("job with uuid %s does not exist", uuid)
+	}
+	return c.states[uuid], nil
 }
 
 func (c *MemoryCatalog) Statistics() CatalogStatistics {
-	var enabled, disabled int
+	var enabled, disabled, completed int
 	jobs := c.All()
 	for _, v := range jobs {
 		switch v.Enabled {
@@ -172,6 +205,10 @@ func (c *MemoryCatalog) Statistics() CatalogStatistics {
 		case false:
 			disabled++
 		}
+
+		if state, _ := c.State(v.Uuid); state == StateCompleted {
+			completed++
+		}
 	}
 
 	var resultCount int
@@ -181,10 +218,11 @@ func (c *MemoryCatalog) Statistics() CatalogStatistics {
 	}
 
 	return CatalogStatistics{
-		Count:         enabled + disabled,
-		EnabledCount:  enabled,
-		DisabledCount: disabled,
-		ResultCount:   resultCount,
+		Count:          enabled + disabled,
+		EnabledCount:   enabled,
+		DisabledCount:  disabled,
+		CompletedCount: completed,
+		ResultCount:    resultCount,
 	}
 }
 
@@ -196,5 +234,6 @@ func (c *MemoryCatalog) Update(job Job) error {
 		return fmt.Errorf("job with uuid %s does not exist", job.Uuid)
 	}
 	c.jobs[job.Uuid] = job
+	c.complete(job)
 	return nil
 }
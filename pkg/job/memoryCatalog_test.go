@@ -1,3 +1,110 @@
 package job
 
-// TODO MemoryCatalog testt
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/jantytgat/go-jobs/pkg/cron"
+)
+
+func TestMemoryCatalog_ReserveRun(t *testing.T) {
+	c := NewMemoryCatalog()
+	limited := New(uuid.New(), "limited", cron.EverySecond(), nil, WithRunLimit(2))
+	unlimited := New(uuid.New(), "unlimited", cron.EverySecond(), nil)
+	for _, j := range []Job{limited, unlimited} {
+		if err := c.Add(j); err != nil {
+			t.Fatalf("cannot add job: %v", err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := c.ReserveRun(limited.Uuid); err != nil {
+			t.Fatalf("run %d should be reserved, received %v", i+1, err)
+		}
+	}
+	if err := c.ReserveRun(limited.Uuid); !errors.Is(err, ErrRunLimitReached) {
+		t.Errorf("run 3 should return ErrRunLimitReached, received %v", err)
+	}
+	if state, _ := c.State(limited.Uuid); state != StateCompleted {
+		t.Errorf("job should be %s, received %s", StateCompleted, state)
+	}
+	if c.CountRuns(limited.Uuid) != 2 {
+		t.Errorf("job should have 2 runs, received %d", c.CountRuns(limited.Uuid))
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := c.ReserveRun(unlimited.Uuid); err != nil {
+			t.Fatalf("run %d should be reserved, received %v", i+1, err)
+		}
+	}
+	if state, _ := c.State(unlimited.Uuid); state != StateActive {
+		t.Errorf("job should be %s, received %s", StateActive, state)
+	}
+
+	if err := c.ReserveRun(uuid.New()); err == nil {
+		t.Errorf("reserving a run for an unknown job should return an error")
+	}
+}
+
+func TestMemoryCatalog_ReserveRunConcurrent(t *testing.T) {
+	c := NewMemoryCatalog()
+	j := New(uuid.New(), "limited", cron.EverySecond(), nil, WithRunLimit(10))
+	if err := c.Add(j); err != nil {
+		t.Fatalf("cannot add job: %v", err)
+	}
+
+	var reserved atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if c.ReserveRun(j.Uuid) == nil {
+				reserved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reserved.Load() != 10 {
+		t.Errorf("exactly 10 runs should be reserved, received %d", reserved.Load())
+	}
+}
+
+func TestMemoryCatalog_Schedulable(t *testing.T) {
+	c := NewMemoryCatalog()
+	j := New(uuid.New(), "limited", cron.EverySecond(), nil, WithRunLimit(1))
+	disabled := New(uuid.New(), "disabled", cron.EverySecond(), nil, WithDisabled())
+	for _, j := range []Job{j, disabled} {
+		if err := c.Add(j); err != nil {
+			t.Fatalf("cannot add job: %v", err)
+		}
+	}
+
+	if len(c.GetSchedulable()) != 2 || len(c.GetNotSchedulable()) != 0 {
+		t.Fatalf("active jobs should be schedulable")
+	}
+
+	if err := c.ReserveRun(j.Uuid); err != nil {
+		t.Fatalf("cannot reserve run: %v", err)
+	}
+	if len(c.GetSchedulable()) != 1 || len(c.GetNotSchedulable()) != 1 || c.GetNotSchedulable()[0].Uuid != j.Uuid {
+		t.Errorf("completed job should not be schedulable")
+	}
+	if stats := c.Statistics(); stats.CompletedCount != 1 {
+		t.Errorf("statistics should count 1 completed job, received %d", stats.CompletedCount)
+	}
+
+	// raising the run limit does not reactivate a completed job
+	j.MaxRuns = 5
+	if err := c.Update(j); err != nil {
+		t.Fatalf("cannot update job: %v", err)
+	}
+	if state, _ := c.State(j.Uuid); state != StateCompleted {
+		t.Errorf("job should stay %s, received %s", StateCompleted, state)
+	}
+}
@@ -1,5 +1,7 @@
 package job
 
+import "github.com/jantytgat/go-jobs/pkg/cron"
+
 type Option func(*Job)
 
 func WithConcurrencyLimit(limit int) Option {
@@ -15,6 +17,22 @@ func WithDisabled() Option {
 	}
 }
 
+// WithMisfirePolicy sets what happens with the times the job was due, but was not triggered.
+// The missed times are evaluated against the trigger time of the last result of the job.
+func WithMisfirePolicy(policy cron.MisfirePolicy) Option {
+	return func(j *Job) {
+		j.MisfirePolicy = policy
+	}
+}
+
+// WithOverlapPolicy sets what happens with a trigger when the job is already running MaxConcurrency times.
+// By default, the trigger is skipped.
+func WithOverlapPolicy(policy OverlapPolicy) Option {
+	return func(j *Job) {
+		j.OverlapPolicy = policy
+	}
+}
+
 func WithRunLimit(limit int) Option {
 	return func(j *Job) {
 		j.LimitRuns = true
@@ -0,0 +1,37 @@
+package job
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	OverlapSkip    OverlapPolicy = iota // the new trigger is skipped
+	OverlapQueue                        // the new trigger waits until a running instance finishes
+	OverlapReplace                      // the oldest running instance is cancelled and the new trigger starts when it has stopped
+)
+
+var overlapPolicyStrings = []string{"skip", "queue", "replace"}
+
+// OverlapPolicy defines what happens with a trigger of a job which is already running MaxConcurrency times.
+type OverlapPolicy int
+
+func (p OverlapPolicy) String() string {
+	return overlapPolicyStrings[p]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p OverlapPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *OverlapPolicy) UnmarshalText(text []byte) error {
+	i := slices.Index(overlapPolicyStrings, strings.ToLower(string(text)))
+	if i < 0 {
+		return fmt.Errorf("invalid overlap policy %s, expected one of %s", text, strings.Join(overlapPolicyStrings, ", "))
+	}
+	*p = OverlapPolicy(i)
+	return nil
+}
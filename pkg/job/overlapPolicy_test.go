@@ -0,0 +1,34 @@
+package job
+
+import "testing"
+
+func TestOverlapPolicy_String(t *testing.T) {
+	var (
+		result []string
+		wanted = overlapPolicyStrings
+	)
+
+	for i := 0; i < len(wanted); i++ {
+		result = append(result, OverlapPolicy(i).String())
+	}
+
+	for j := 0; j < len(wanted); j++ {
+		if result[j] != wanted[j] {
+			t.Errorf("invalid string: got %s expected %s", result[j], wanted[j])
+		}
+	}
+}
+
+func TestOverlapPolicy_UnmarshalText(t *testing.T) {
+	for i, s := range overlapPolicyStrings {
+		var p OverlapPolicy
+		if err := p.UnmarshalText([]byte(s)); err != nil || p != OverlapPolicy(i) {
+			t.Errorf("%s should unmarshal to %d, received %d (%v)", s, i, p, err)
+		}
+	}
+
+	var p OverlapPolicy
+	if err := p.UnmarshalText([]byte("wait")); err == nil {
+		t.Errorf("unknown overlap policy should return an error")
+	}
+}
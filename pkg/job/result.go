@@ -11,6 +11,8 @@ import (
 type Result struct {
 	Uuid        uuid.UUID
 	RunUuid     uuid.UUID
+	Status      Status
+	Trigger     Trigger
 	TriggerTime time.Time
 	RunTime     time.Duration
 	TaskResults []task.Result
@@ -0,0 +1,194 @@
+package job
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSimulatedRunTime     = 1 * time.Second
+	defaultSimulatedMaxTriggers = 100000
+)
+
+// Simulate projects when the enabled jobs are triggered from (inclusive) until to (exclusive), using their schedules only.
+// No tasks are executed. Each run is assumed to take the run time set with WithSimulatedRunTime, which defaults to a second.
+func Simulate(jobs []Job, from time.Time, to time.Time, opts ...SimulationOption) Simulation {
+	cfg := simulationConfig{
+		runTime:     defaultSimulatedRunTime,
+		runTimes:    make(map[uuid.UUID]time.Duration),
+		maxTriggers: defaultSimulatedMaxTriggers,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	s := Simulation{
+		From: from,
+		To:   to,
+	}
+
+	perSecond := make(map[int64]int)
+	for _, j := range jobs {
+		if !j.Enabled || j.Schedule == nil {
+			continue
+		}
+
+		sj := cfg.project(j, from, to)
+		for _, trigger := range sj.Triggers {
+			perSecond[trigger.Unix()]++
+		}
+		s.Conflicts = append(s.Conflicts, cfg.conflicts(j, sj.Triggers)...)
+		s.Jobs = append(s.Jobs, sj)
+	}
+
+	for second, count := range perSecond {
+		switch {
+		case count > s.Peak:
+			s.Peak = count
+			s.PeakTimes = []time.Time{time.Unix(second, 0).In(from.Location())}
+		case count == s.Peak:
+			s.PeakTimes = append(s.PeakTimes, time.Unix(second, 0).In(from.Location()))
+		}
+	}
+	sort.Slice(s.PeakTimes, func(i, k int) bool { return s.PeakTimes[i].Before(s.PeakTimes[k]) })
+
+	sort.Slice(s.Jobs, func(i, k int) bool {
+		if s.Jobs[i].Name != s.Jobs[k].Name {
+			return s.Jobs[i].Name < s.Jobs[k].Name
+		}
+		return s.Jobs[i].Uuid.String() < s.Jobs[k].Uuid.String()
+	})
+	sort.SliceStable(s.Conflicts, func(i, k int) bool { return s.Conflicts[i].Time.Before(s.Conflicts[k].Time) })
+	return s
+}
+
+// SimulateCatalog projects when the enabled jobs in catalog c are triggered from (inclusive) until to (exclusive).
+// Jobs with a run limit only project the runs which are left according to the runs reserved in the catalog.
+func SimulateCatalog(c Catalog, from time.Time, to time.Time, opts ...SimulationOption) Simulation {
+	all := c.All()
+	jobs := make([]Job, 0, len(all))
+	for id, j := range all {
+		if j.LimitRuns {
+			j.MaxRuns = max(j.MaxRuns-c.CountRuns(id), 0)
+		}
+		jobs = append(jobs, j)
+	}
+	return Simulate(jobs, from, to, opts...)
+}
+
+// Simulation is the outcome of a schedule simulation.
+type Simulation struct {
+	From      time.Time
+	To        time.Time
+	Jobs      []SimulatedJob // projected triggers per job, ordered by name
+	Peak      int            // highest number of triggers within the same second
+	PeakTimes []time.Time    // seconds in which Peak triggers are projected
+	Conflicts []Conflict     // triggers exceeding the concurrency limit of their job, ordered by time
+}
+
+// Triggers returns the projected triggers of the job with the given uuid.
+func (s Simulation) Triggers(uuid uuid.UUID) []time.Time {
+	for _, j := range s.Jobs {
+		if j.Uuid == uuid {
+			return j.Triggers
+		}
+	}
+	return nil
+}
+
+// SimulatedJob holds the projected triggers of a job.
+type SimulatedJob struct {
+	Uuid      uuid.UUID
+	Name      string
+	Triggers  []time.Time // ordered trigger times
+	Truncated bool        // true when the triggers were limited by WithSimulatedMaxTriggers
+}
+
+// Conflict is a projected trigger of a job at which the job is still running MaxConcurrency times.
+type Conflict struct {
+	Uuid           uuid.UUID
+	Name           string
+	Time           time.Time
+	Running        int
+	MaxConcurrency int
+}
+
+type simulationConfig struct {
+	runTime     time.Duration
+	runTimes    map[uuid.UUID]time.Duration
+	maxTriggers int
+}
+
+// project returns the triggers of job j from (inclusive) until to (exclusive).
+func (c simulationConfig) project(j Job, from time.Time, to time.Time) SimulatedJob {
+	sj := SimulatedJob{
+		Uuid: j.Uuid,
+		Name: j.Name,
+	}
+
+	// the run limit of the job stops the projection before the maximum number of triggers, without truncating it
+	limit := c.maxTriggers
+	runLimited := j.LimitRuns && j.MaxRuns <= limit
+	if runLimited {
+		limit = j.MaxRuns
+	}
+
+	cursor := from.Add(-time.Nanosecond)
+	for {
+		next, ok := j.Schedule.Next(cursor)
+		if !ok || !next.Before(to) {
+			break
+		}
+		if len(sj.Triggers) == limit {
+			sj.Truncated = !runLimited
+			break
+		}
+		sj.Triggers = append(sj.Triggers, next)
+		cursor = next
+	}
+	return sj
+}
+
+// conflicts returns the triggers at which job j would exceed its concurrency limit.
+// Runs are assumed to take the simulated run time. Triggers exceeding the limit are handled by the overlap policy of the job:
+// they do not start a run, start when the first running instance finishes, or replace the oldest running instance.
+func (c simulationConfig) conflicts(j Job, triggers []time.Time) []Conflict {
+	if !j.LimitConcurrency || j.MaxConcurrency < 1 {
+		return nil
+	}
+
+	runTime, ok := c.runTimes[j.Uuid]
+	if !ok {
+		runTime = c.runTime
+	}
+
+	var conflicts []Conflict
+	var running []time.Time // end times of the runs in progress, in order
+	for _, trigger := range triggers {
+		for len(running) > 0 && !running[0].After(trigger) {
+			running = running[1:]
+		}
+
+		if len(running) >= j.MaxConcurrency {
+			conflicts = append(conflicts, Conflict{
+				Uuid:           j.Uuid,
+				Name:           j.Name,
+				Time:           trigger,
+				Running:        len(running),
+				MaxConcurrency: j.MaxConcurrency,
+			})
+
+			switch j.OverlapPolicy {
+			case OverlapQueue:
+				running = append(running[1:], running[0].Add(runTime))
+			case OverlapReplace:
+				running = append(running[1:], trigger.Add(runTime))
+			}
+			continue
+		}
+		running = append(running, trigger.Add(runTime))
+	}
+	return conflicts
+}
@@ -0,0 +1,30 @@
+package job
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SimulationOption func(*simulationConfig)
+
+// WithSimulatedRunTime sets how long each run is assumed to take, to detect concurrency conflicts.
+func WithSimulatedRunTime(d time.Duration) SimulationOption {
+	return func(c *simulationConfig) {
+		c.runTime = d
+	}
+}
+
+// WithSimulatedJobRunTime sets how long each run of the job with the given uuid is assumed to take.
+func WithSimulatedJobRunTime(uuid uuid.UUID, d time.Duration) SimulationOption {
+	return func(c *simulationConfig) {
+		c.runTimes[uuid] = d
+	}
+}
+
+// WithSimulatedMaxTriggers limits the number of projected triggers per job, to bound schedules which are due often.
+func WithSimulatedMaxTriggers(limit int) SimulationOption {
+	return func(c *simulationConfig) {
+		c.maxTriggers = limit
+	}
+}
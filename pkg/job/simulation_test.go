@@ -0,0 +1,145 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jantytgat/go-jobs/pkg/cron"
+)
+
+func mustParse(t *testing.T, expression string, opts ...cron.ScheduleOption) cron.Timetable {
+	t.Helper()
+
+	tt, err := cron.Parse(expression, opts...)
+	if err != nil {
+		t.Fatalf("cannot parse %s: %v", expression, err)
+	}
+	return tt
+}
+
+func TestSimulate(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	to := from.Add(24 * time.Hour)
+
+	hourly := New(uuid.New(), "hourly", mustParse(t, "0 * * * *"), nil)
+	quarterly := New(uuid.New(), "quarterly", mustParse(t, "*/15 * * * *"), nil, WithConcurrencyLimit(1))
+	limited := New(uuid.New(), "limited", mustParse(t, "30 * * * *"), nil, WithRunLimit(3))
+	disabled := New(uuid.New(), "disabled", mustParse(t, "* * * * *"), nil, WithDisabled())
+
+	s := Simulate([]Job{quarterly, hourly, limited, disabled}, from, to,
+		WithSimulatedRunTime(time.Minute),
+		WithSimulatedJobRunTime(quarterly.Uuid, 20*time.Minute))
+
+	if len(s.Jobs) != 3 {
+		t.Fatalf("simulation should contain 3 jobs, received %d", len(s.Jobs))
+	}
+	for i, name := range []string{"hourly", "limited", "quarterly"} {
+		if s.Jobs[i].Name != name {
+			t.Errorf("job %d should be %s, received %s", i, name, s.Jobs[i].Name)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		uuid  uuid.UUID
+		count int
+		first time.Time
+	}{
+		{name: "hourly", uuid: hourly.Uuid, count: 24, first: from},
+		{name: "quarterly", uuid: quarterly.Uuid, count: 96, first: from},
+		{name: "limited", uuid: limited.Uuid, count: 3, first: from.Add(30 * time.Minute)},
+		{name: "disabled", uuid: disabled.Uuid, count: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			triggers := s.Triggers(tt.uuid)
+			if len(triggers) != tt.count {
+				t.Fatalf("job should be triggered %d times, received %d", tt.count, len(triggers))
+			}
+			if tt.count > 0 && !triggers[0].Equal(tt.first) {
+				t.Errorf("first trigger should be %s, received %s", tt.first, triggers[0])
+			}
+			for i := 1; i < len(triggers); i++ {
+				if !triggers[i].After(triggers[i-1]) {
+					t.Errorf("trigger %s should be after %s", triggers[i], triggers[i-1])
+				}
+			}
+		})
+	}
+
+	// quarterly coincides with hourly every hour, and with limited during its 3 runs
+	if s.Peak != 2 || len(s.PeakTimes) != 27 {
+		t.Errorf("peak should be 2 triggers in 27 seconds, received %d in %d seconds", s.Peak, len(s.PeakTimes))
+	}
+
+	// runs of quarterly take 20 minutes, so every other trigger conflicts with its concurrency limit of 1
+	if len(s.Conflicts) != 48 {
+		t.Fatalf("simulation should contain 48 conflicts, received %d", len(s.Conflicts))
+	}
+	if c := s.Conflicts[0]; c.Uuid != quarterly.Uuid || !c.Time.Equal(from.Add(15*time.Minute)) || c.Running != 1 {
+		t.Errorf("first conflict should be quarterly at %s with 1 run, received %s at %s with %d runs", from.Add(15*time.Minute), c.Name, c.Time, c.Running)
+	}
+}
+
+func TestSimulateCatalog(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+
+	limited := New(uuid.New(), "limited", mustParse(t, "0 * * * *"), nil, WithRunLimit(5))
+	c := NewMemoryCatalog()
+	if err := c.Add(limited); err != nil {
+		t.Fatalf("cannot add job: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := c.ReserveRun(limited.Uuid); err != nil {
+			t.Fatalf("cannot reserve run: %v", err)
+		}
+	}
+
+	s := SimulateCatalog(c, from, from.Add(24*time.Hour))
+	if triggers := s.Triggers(limited.Uuid); len(triggers) != 3 {
+		t.Errorf("job should be triggered for the 3 runs left, received %d", len(triggers))
+	}
+}
+
+func TestSimulate_MaxTriggers(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+
+	j := New(uuid.New(), "every second", cron.EverySecond(), nil)
+	s := Simulate([]Job{j}, from, from.Add(24*time.Hour), WithSimulatedMaxTriggers(60))
+	if len(s.Jobs[0].Triggers) != 60 || !s.Jobs[0].Truncated {
+		t.Errorf("job should be truncated at 60 triggers, received %d", len(s.Jobs[0].Triggers))
+	}
+
+	// a job which reaches its run limit is not truncated, also when the run limit equals the maximum number of triggers
+	limited := New(uuid.New(), "limited", cron.EverySecond(), nil, WithRunLimit(60))
+	s = Simulate([]Job{limited}, from, from.Add(24*time.Hour), WithSimulatedMaxTriggers(60))
+	if len(s.Jobs[0].Triggers) != 60 || s.Jobs[0].Truncated {
+		t.Errorf("job should stop at its run limit of 60 triggers without being truncated, received %d, truncated %t", len(s.Jobs[0].Triggers), s.Jobs[0].Truncated)
+	}
+}
+
+func TestSimulate_OverlapPolicy(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+
+	// runs take 25 minutes and the job is triggered every 10 minutes
+	tests := []struct {
+		policy OverlapPolicy
+		wanted int
+	}{
+		{policy: OverlapSkip, wanted: 2},    // 00:10 and 00:20 are skipped, 00:30 starts
+		{policy: OverlapQueue, wanted: 3},   // every trigger waits, so the queue keeps growing
+		{policy: OverlapReplace, wanted: 3}, // every trigger replaces the running instance
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			j := New(uuid.New(), "slow", mustParse(t, "*/10 * * * *"), nil, WithOverlapPolicy(tt.policy))
+			s := Simulate([]Job{j}, from, from.Add(40*time.Minute), WithSimulatedRunTime(25*time.Minute))
+			if len(s.Conflicts) != tt.wanted {
+				t.Errorf("simulation should contain %d conflicts, received %d", tt.wanted, len(s.Conflicts))
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package job
+
+const (
+	StateActive    State = iota // the job is scheduled when it is enabled
+	StateCompleted              // the job reached its run limit, and is not scheduled anymore
+)
+
+var stateStrings = []string{"active", "completed"}
+
+// State is the lifecycle state of a job in a catalog.
+type State int
+
+func (s State) String() string {
+	return stateStrings[s]
+}
@@ -0,0 +1,19 @@
+package job
+
+const (
+	StatusNone      Status = iota
+	StatusSuccess          // all tasks of the run succeeded
+	StatusError            // the run failed
+	StatusSkipped          // the trigger was skipped, because the job was running MaxConcurrency times or a requested run could not be dispatched
+	StatusReplaced         // the run was cancelled, because a new trigger replaced it
+	StatusCancelled        // the run was cancelled on request
+)
+
+var statusStrings = []string{"none", "success", "error", "skipped", "replaced", "cancelled-by-user"}
+
+// Status is the outcome of a run of a job.
+type Status int
+
+func (s Status) String() string {
+	return statusStrings[s]
+}
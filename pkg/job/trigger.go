@@ -0,0 +1,16 @@
+package job
+
+const (
+	TriggerSchedule Trigger = iota // the run was triggered by the schedule of the job
+	TriggerManual                  // the run was triggered on request, outside the schedule of the job
+	TriggerBackfill                // the run replays a past fire time of the schedule of the job
+)
+
+var triggerStrings = []string{"schedule", "manual", "backfill"}
+
+// Trigger is what caused a run of a job.
+type Trigger int
+
+func (t Trigger) String() string {
+	return triggerStrings[t]
+}
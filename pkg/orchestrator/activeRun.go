@@ -0,0 +1,21 @@
+package orchestrator
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jantytgat/go-jobs/pkg/job"
+	"github.com/jantytgat/go-jobs/pkg/task"
+)
+
+// ActiveRun is a snapshot of a run which is running, see Orchestrator.ActiveRuns.
+type ActiveRun struct {
+	JobUuid     uuid.UUID
+	RunUuid     uuid.UUID
+	Trigger     job.Trigger
+	TriggerTime time.Time
+	StartTime   time.Time
+	Elapsed     time.Duration // time since StartTime
+	Progress    task.Progress // current task of the run and its progress
+}
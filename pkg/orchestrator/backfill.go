@@ -0,0 +1,147 @@
+package orchestrator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jantytgat/go-jobs/pkg/job"
+)
+
+// maxBackfillRuns is the maximum number of fire times in the range of a backfill.
+const maxBackfillRuns = 100000
+
+// BackfillProgress is the progress of a backfill.
+type BackfillProgress struct {
+	Total     int // runs of the backfill, one for each fire time in the range
+	Queued    int // runs which were queued
+	Succeeded int // runs which finished with job.StatusSuccess
+	Failed    int // runs which finished with another status, for example because they returned an error or were skipped
+}
+
+// Finished returns the number of runs which have a result.
+func (p BackfillProgress) Finished() int {
+	return p.Succeeded + p.Failed
+}
+
+// Backfill replays the fire times of a job over a past time range, see Orchestrator.Backfill.
+type Backfill struct {
+	Uuid        uuid.UUID // uuid of the job
+	From        time.Time
+	To          time.Time
+	times       []time.Time // fire times in the range, in order
+	parallelism int
+	progress    BackfillProgress
+	err         error
+	chResults   chan job.Result
+	done        chan struct{}
+	cancel      context.CancelFunc
+	mux         sync.Mutex
+}
+
+func newBackfill(jobUuid uuid.UUID, from time.Time, to time.Time, times []time.Time, parallelism int) *Backfill {
+	return &Backfill{
+		Uuid:        jobUuid,
+		From:        from,
+		To:          to,
+		times:       times,
+		parallelism: parallelism,
+		progress:    BackfillProgress{Total: len(times)},
+		chResults:   make(chan job.Result, parallelism),
+		done:        make(chan struct{}),
+	}
+}
+
+// Cancel stops queuing runs and cancels the runs which are queued already, see Orchestrator.CancelRun.
+// Done is closed when the cancelled runs have a result.
+func (b *Backfill) Cancel() {
+	b.cancel()
+}
+
+// Done returns a channel which is closed when the backfill finished or was cancelled.
+func (b *Backfill) Done() <-chan struct{} {
+	return b.done
+}
+
+// Err returns nil if all runs of the backfill were queued and have a result.
+// If the backfill was cancelled or the orchestrator stopped before, it returns the error of the context.
+// The results of the runs are in the catalog.
+func (b *Backfill) Err() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.err
+}
+
+// Progress returns the progress of the backfill.
+func (b *Backfill) Progress() BackfillProgress {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.progress
+}
+
+// run queues the fire times in the queue of orchestrator o, keeping at most parallelism runs in flight until all of them finished.
+// When ctx is done, the backfill stops queuing and cancels the runs in flight, but still waits for their results until the orchestrator context oCtx is done.
+func (b *Backfill) run(ctx context.Context, oCtx context.Context, o *Orchestrator) {
+	defer close(b.done)
+	defer b.cancel()
+
+	inFlight := make(map[uuid.UUID]struct{}, b.parallelism)
+	next := 0
+	chCancel := ctx.Done()
+	for {
+		for len(inFlight) < b.parallelism && next < len(b.times) && ctx.Err() == nil {
+			tick := SchedulerTick{
+				uuid:    b.Uuid,
+				time:    b.times[next],
+				run:     uuid.New(),
+				trigger: job.TriggerBackfill,
+			}
+			o.watchers.watch(tick.run, b.chResults)
+			o.runs.queue(tick.run)
+			o.push(tick)
+
+			inFlight[tick.run] = struct{}{}
+			next++
+			b.mux.Lock()
+			b.progress.Queued++
+			b.mux.Unlock()
+		}
+
+		if len(inFlight) == 0 {
+			break
+		}
+
+		select {
+		case <-oCtx.Done():
+			for runUuid := range inFlight {
+				o.watchers.unwatch(runUuid)
+			}
+			b.mux.Lock()
+			b.err = oCtx.Err()
+			b.mux.Unlock()
+			return
+		case <-chCancel:
+			chCancel = nil // the runs in flight are cancelled once, their results are still waited for
+			for runUuid := range inFlight {
+				_ = o.runs.cancel(runUuid, errRunCancelled)
+			}
+		case r := <-b.chResults:
+			delete(inFlight, r.RunUuid)
+			b.mux.Lock()
+			if r.Status == job.StatusSuccess {
+				b.progress.Succeeded++
+			} else {
+				b.progress.Failed++
+			}
+			b.mux.Unlock()
+		}
+	}
+
+	if next < len(b.times) {
+		b.mux.Lock()
+		b.err = ctx.Err()
+		b.mux.Unlock()
+	}
+}
@@ -2,18 +2,18 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
-	"time"
-
-	"github.com/google/uuid"
 
+	"github.com/jantytgat/go-jobs/pkg/cron"
 	"github.com/jantytgat/go-jobs/pkg/job"
 	"github.com/jantytgat/go-jobs/pkg/task"
 )
 
-func newDispatcher(logger *slog.Logger, maxRunners int, chDispatcher chan dispatcherMessage, chResults chan job.Result) *dispatcher {
+func newDispatcher(logger *slog.Logger, clock cron.Clock, maxRunners int, chDispatcher chan dispatcherMessage, chResults chan job.Result) *dispatcher {
 	if maxRunners < 1 {
 		maxRunners = 1
 	}
@@ -23,6 +23,7 @@ func newDispatcher(logger *slog.Logger, maxRunners int, chDispatcher chan dispat
 		chResults:    chResults,
 		runners:      make(map[int]context.CancelFunc),
 		logger:       logger,
+		clock:        clock,
 	}
 }
 
@@ -33,6 +34,7 @@ type dispatcher struct {
 	chResults    chan job.Result
 	runners      map[int]context.CancelFunc
 	logger       *slog.Logger
+	clock        cron.Clock
 	mux          sync.Mutex
 }
 
@@ -51,64 +53,71 @@ func (d *dispatcher) IsRunning() bool {
 	return false
 }
 
+// Start launches the runners of the dispatcher, which run the jobs sent to the dispatcher until ctx is done or the dispatcher is stopped.
 func (d *dispatcher) Start(ctx context.Context) error {
-	var dispatchCtx context.Context
-	dispatchCtx, d.cancelFunc = context.WithCancel(ctx)
+	d.mux.Lock()
+	defer d.mux.Unlock()
 
-	go d.run(dispatchCtx)
+	if d.cancelFunc != nil {
+		return fmt.Errorf("dispatcher already started")
+	}
 
-	startCtx, startCancel := context.WithTimeout(ctx, 1*time.Second)
-	defer startCancel()
+	var dispatchCtx context.Context
+	dispatchCtx, d.cancelFunc = context.WithCancel(ctx)
 
-	for {
-		select {
-		case <-startCtx.Done():
-			d.logger.LogAttrs(ctx, slog.LevelError, "dispatcher start timeout")
-			return fmt.Errorf("dispatcher start timeout")
-		default:
-			if d.IsRunning() { // TODO add timeout to start before returning an error?
-				d.logger.LogAttrs(ctx, slog.LevelDebug, "dispatcher has started")
-				return nil
-			}
-		}
+	chRunnerDone := make(chan int, d.maxRunners)
+	for i := 0; i < d.maxRunners; i++ {
+		d.startRunner(dispatchCtx, i, chRunnerDone)
 	}
+	go d.run(dispatchCtx, chRunnerDone)
+
+	d.logger.LogAttrs(ctx, slog.LevelDebug, "dispatcher has started")
+	return nil
 }
 
 func (d *dispatcher) Stop(ctx context.Context) {
 	d.mux.Lock()
+	defer d.mux.Unlock()
+
 	if d.cancelFunc == nil {
 		d.logger.LogAttrs(ctx, slog.LevelWarn, "dispatcher has stopped already")
 		return
 	}
-	d.mux.Unlock()
 
 	d.logger.LogAttrs(ctx, slog.LevelDebug, "dispatcher stopping")
 	d.cancelFunc()
-
-	d.mux.Lock()
 	d.cancelFunc = nil
-	d.mux.Unlock()
 }
 
-func (d *dispatcher) run(ctx context.Context) {
+// run replaces each runner which finished a job, until ctx is done.
+func (d *dispatcher) run(ctx context.Context, chRunnerDone chan int) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		default:
+		case id := <-chRunnerDone:
 			d.mux.Lock()
-			for i := 0; i < d.maxRunners; i++ {
-				if _, found := d.runners[i]; !found {
-					runnerCtx, runnerCancel := context.WithCancel(ctx)
-					d.runners[i] = runnerCancel
-					go d.launchRunner(runnerCtx, i)
-				}
-			}
+			d.startRunner(ctx, id, chRunnerDone)
 			d.mux.Unlock()
 		}
 	}
 }
 
+// startRunner launches runner id, which sends its id to chRunnerDone when it finished; the caller must hold the lock.
+func (d *dispatcher) startRunner(ctx context.Context, id int, chRunnerDone chan<- int) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	runnerCtx, runnerCancel := context.WithCancel(ctx)
+	d.runners[id] = runnerCancel
+	go func() {
+		defer func() { chRunnerDone <- id }()
+		defer runnerCancel()
+		d.launchRunner(runnerCtx, id)
+	}()
+}
+
 func (d *dispatcher) deleteRunner(id int) {
 	d.mux.Lock()
 	defer d.mux.Unlock()
@@ -126,16 +135,37 @@ func (d *dispatcher) launchRunner(ctx context.Context, id int) {
 	case <-ctx.Done():
 		return
 	case msg := <-d.chDispatcher:
-		startTime := time.Now()
+		startTime := d.clock.Now()
+		runCtx, runCancel := msg.run.start(ctx, startTime)
+		defer runCancel(nil)
+
 		l := d.logger.WithGroup("job").With(slog.Int("dispatcher_id", id), slog.String("id", msg.job.Uuid.String()))
-		runUuid := uuid.New()
-		l.LogAttrs(ctx, slog.LevelInfo, "job starting", slog.String("instance", runUuid.String()))
-		taskResults, err := task.ExecuteSequence(ctx, l, msg.job.Tasks, msg.handlerRepository)
-		l.LogAttrs(ctx, slog.LevelInfo, "job finished", slog.String("instance", runUuid.String()))
-		duration := time.Since(startTime)
+		runUuid := msg.run.uuid
+
+		var taskResults []task.Result
+		var err error
+		if runCtx.Err() == nil { // the run can be replaced before it starts
+			l.LogAttrs(ctx, slog.LevelInfo, "job starting", slog.String("instance", runUuid.String()))
+			taskResults, err = task.ExecuteSequence(runCtx, l, msg.job.Tasks, msg.handlerRepository, task.WithPipelineTriggerTime(msg.triggerTime), task.WithPipelineProgress(msg.run.setProgress))
+			l.LogAttrs(ctx, slog.LevelInfo, "job finished", slog.String("instance", runUuid.String()))
+		}
+		duration := d.clock.Now().Sub(startTime)
+
+		status := job.StatusSuccess
+		switch cause := context.Cause(runCtx); {
+		case errors.Is(cause, errRunReplaced):
+			status, err = job.StatusReplaced, cause
+		case errors.Is(cause, errRunCancelled):
+			status, err = job.StatusCancelled, cause
+		case err != nil || slices.ContainsFunc(taskResults, func(r task.Result) bool { return r.Status == task.StatusError }):
+			status = job.StatusError
+		}
+
 		result := job.Result{
 			Uuid:        msg.job.Uuid,
-			RunUuid:     uuid.New(),
+			RunUuid:     runUuid,
+			Status:      status,
+			Trigger:     msg.trigger,
 			TriggerTime: msg.triggerTime,
 			RunTime:     duration,
 			TaskResults: taskResults,
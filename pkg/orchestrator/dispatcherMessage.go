@@ -11,4 +11,6 @@ type dispatcherMessage struct {
 	job               job.Job
 	handlerRepository *task.HandlerRepository
 	triggerTime       time.Time
+	trigger           job.Trigger
+	run               *run
 }
@@ -3,6 +3,7 @@ package orchestrator
 import (
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/jantytgat/go-jobs/pkg/cron"
 	"github.com/jantytgat/go-jobs/pkg/job"
 	"github.com/jantytgat/go-jobs/pkg/task"
 )
@@ -34,3 +35,11 @@ func WithQueue(q Queue) Option {
 		o.queue = q
 	}
 }
+
+// WithClock sets the clock used by the scheduler and dispatcher, for example a cron.FakeClock in tests.
+// By default, the orchestrator uses cron.SystemClock.
+func WithClock(c cron.Clock) Option {
+	return func(o *Orchestrator) {
+		o.clock = c
+	}
+}
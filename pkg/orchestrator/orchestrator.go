@@ -3,12 +3,15 @@ package orchestrator
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/jantytgat/go-jobs/pkg/cron"
 	"github.com/jantytgat/go-jobs/pkg/job"
 	"github.com/jantytgat/go-jobs/pkg/task"
 )
@@ -30,11 +33,12 @@ func New(logger *slog.Logger, name string, maxRunners int, opts ...Option) (*Orc
 
 	o := &Orchestrator{
 		name:         name,
-		scheduler:    newScheduler(logger, chScheduler, chTick),
-		dispatcher:   newDispatcher(logger, maxRunners, chDispatcher, chResults),
+		runs:         newRunRegistry(),
+		watchers:     newRunWatchers(),
 		chScheduler:  chScheduler,
 		chDispatcher: chDispatcher,
 		chTick:       chTick,
+		chQueued:     make(chan struct{}, 1),
 		chResults:    chResults,
 		logger:       logger,
 		maxRunners:   maxRunners,
@@ -45,6 +49,12 @@ func New(logger *slog.Logger, name string, maxRunners int, opts ...Option) (*Orc
 		opt(o)
 	}
 
+	if o.clock == nil {
+		o.clock = cron.SystemClock()
+	}
+	o.scheduler = newScheduler(logger, o.clock, chScheduler, chTick)
+	o.dispatcher = newDispatcher(logger, o.clock, maxRunners, chDispatcher, chResults)
+
 	if o.reg == nil {
 		o.reg = prometheus.NewRegistry()
 	}
@@ -66,10 +76,13 @@ func New(logger *slog.Logger, name string, maxRunners int, opts ...Option) (*Orc
 
 type Orchestrator struct {
 	name         string
+	ctx          context.Context // context of the running orchestrator, nil if it is not running
 	cancelFunc   context.CancelFunc
-	scheduler    *scheduler  // manages tickers for job schedule
-	queue        Queue       // jobs to be queued for execution
-	dispatcher   *dispatcher // manages job runners
+	scheduler    *scheduler   // manages tickers for job schedule
+	queue        Queue        // jobs to be queued for execution
+	dispatcher   *dispatcher  // manages job runners
+	runs         *runRegistry // runs which are dispatched and not finished yet
+	watchers     *runWatchers // channels waiting for the result of a run
 	logger       *slog.Logger
 	Catalog      job.Catalog             // contains jobs
 	Handlers     *task.HandlerRepository // contains task handlers
@@ -77,17 +90,91 @@ type Orchestrator struct {
 	chDispatcher chan dispatcherMessage  // channel to send jobs to dispatcher
 	chResults    chan job.Result         // channel to get results from dispatcher
 	chTick       chan SchedulerTick      // channel to receive ticks from scheduler
+	chQueued     chan struct{}           // channel to signal the queue processor that a tick was pushed
 	maxRunners   int
+	clock        cron.Clock // provides the time to the scheduler and dispatcher
 	reg          prometheus.Registerer
 	mux          sync.Mutex
 }
 
+// ActiveRuns returns the runs which are running, in order of their start time.
+// Each run reports the task it is executing, and the progress reported by the handler of the task through task.Pipeline.
+func (o *Orchestrator) ActiveRuns() []ActiveRun {
+	return o.runs.running(o.clock.Now())
+}
+
+// Backfill replays the job with uuid jobUuid for each fire time of its schedule from (inclusive) until to (exclusive), for example to catch up after an outage.
+// At most parallelism runs are queued at the same time. The runs go through the queue and dispatcher like scheduled runs,
+// but wait for a slot when the job is running MaxConcurrency times, whatever its overlap policy.
+// Each run sees its fire time through task.Pipeline.TriggerTime, and its result has Trigger set to job.TriggerBackfill.
+//
+// The orchestrator must be running. Use the returned Backfill to follow its progress or cancel it.
+func (o *Orchestrator) Backfill(jobUuid uuid.UUID, from time.Time, to time.Time, parallelism int) (*Backfill, error) {
+	o.mux.Lock()
+	oCtx := o.ctx
+	o.mux.Unlock()
+	if oCtx == nil {
+		return nil, errors.New("orchestrator not running")
+	}
+
+	if !from.Before(to) {
+		return nil, fmt.Errorf("backfill range from %s until %s is empty", from, to)
+	}
+	if parallelism < 1 {
+		return nil, fmt.Errorf("backfill parallelism must be at least 1, received %d", parallelism)
+	}
+
+	j, err := o.Catalog.Get(jobUuid)
+	if err != nil {
+		return nil, err
+	}
+	if state, _ := o.Catalog.State(jobUuid); state == job.StateCompleted {
+		return nil, fmt.Errorf("job with uuid %s: %w", jobUuid, job.ErrRunLimitReached)
+	}
+
+	var times []time.Time
+	cursor := from.Add(-time.Nanosecond)
+	for {
+		next, ok := j.Schedule.Next(cursor)
+		if !ok || !next.Before(to) {
+			break
+		}
+		if len(times) == maxBackfillRuns {
+			return nil, fmt.Errorf("backfill range from %s until %s has more than %d fire times", from, to, maxBackfillRuns)
+		}
+		times = append(times, next)
+		cursor = next
+	}
+
+	b := newBackfill(jobUuid, from, to, times, parallelism)
+	var ctx context.Context
+	ctx, b.cancel = context.WithCancel(oCtx)
+	go b.run(ctx, oCtx, o)
+
+	o.logger.LogAttrs(oCtx, slog.LevelInfo, "job backfill started", slog.String("job", jobUuid.String()), slog.Int("runs", len(times)))
+	return b, nil
+}
+
+// CancelRun cancels the run with uuid runUuid, which can be queued, waiting for a slot or running.
+// The context of the task which runs is cancelled, and the result of the run has status job.StatusCancelled.
+// Returns ErrRunNotFound if the run is unknown or finished already.
+func (o *Orchestrator) CancelRun(runUuid uuid.UUID) error {
+	if err := o.runs.cancel(runUuid, errRunCancelled); err != nil {
+		return fmt.Errorf("run with uuid %s: %w", runUuid, err)
+	}
+
+	o.logger.LogAttrs(context.Background(), slog.LevelInfo, "run cancelled", slog.String("instance", runUuid.String()))
+	return nil
+}
+
 func (o *Orchestrator) Start(ctx context.Context) error {
 	o.mux.Lock()
 	defer o.mux.Unlock()
 
 	var oCtx context.Context
 	oCtx, o.cancelFunc = context.WithCancel(ctx)
+	o.ctx = oCtx
+	o.Handlers.Start(oCtx)
 
 	var err error
 	if err = o.dispatcher.Start(oCtx); err != nil {
@@ -117,6 +204,7 @@ func (o *Orchestrator) Statistics() Statistics {
 	return Statistics{
 		HandlerPoolStatistics: handlerPoolStats,
 		QueueLength:           queueLength,
+		ActiveRuns:            o.ActiveRuns(),
 	}
 }
 
@@ -125,9 +213,59 @@ func (o *Orchestrator) Stop() {
 	defer o.mux.Unlock()
 	if o.cancelFunc != nil {
 		o.cancelFunc()
+		o.ctx = nil
 	}
 }
 
+// Trigger queues a run of the job with uuid jobUuid now, outside the schedule of the job, and returns the uuid of the run.
+// The run goes through the queue and dispatcher like scheduled runs, so the concurrency and run limits of the job apply.
+// Disabled jobs can be triggered. The result of the run has Trigger set to job.TriggerManual.
+//
+// The orchestrator must be running.
+func (o *Orchestrator) Trigger(ctx context.Context, jobUuid uuid.UUID, opts ...TriggerOption) (uuid.UUID, error) {
+	if err := ctx.Err(); err != nil {
+		return uuid.Nil, err
+	}
+
+	o.mux.Lock()
+	oCtx := o.ctx
+	o.mux.Unlock()
+	if oCtx == nil {
+		return uuid.Nil, errors.New("orchestrator not running")
+	}
+
+	j, err := o.Catalog.Get(jobUuid)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if state, _ := o.Catalog.State(jobUuid); state == job.StateCompleted {
+		return uuid.Nil, fmt.Errorf("job with uuid %s: %w", jobUuid, job.ErrRunLimitReached)
+	}
+
+	tr := newTrigger(j.Tasks)
+	for _, opt := range opts {
+		opt(tr)
+	}
+	if tr.err != nil {
+		return uuid.Nil, fmt.Errorf("invalid trigger for job with uuid %s: %w", jobUuid, tr.err)
+	}
+
+	tick := SchedulerTick{
+		uuid:    jobUuid,
+		time:    o.clock.Now(),
+		run:     uuid.New(),
+		trigger: job.TriggerManual,
+	}
+	if tr.overridden {
+		tick.tasks = tr.tasks
+	}
+	o.runs.queue(tick.run)
+	o.push(tick)
+
+	o.logger.LogAttrs(ctx, slog.LevelInfo, "job triggered", slog.String("job", jobUuid.String()), slog.String("instance", tick.run.String()))
+	return tick.run, nil
+}
+
 func (o *Orchestrator) checkNotSchedulable(ctx context.Context) {
 	o.logger.LogAttrs(ctx, slog.LevelDebug, "starting catalog checker for not schedulable jobs")
 	defer o.logger.LogAttrs(ctx, slog.LevelDebug, "stopping catalog checker for not schedulable jobs")
@@ -146,7 +284,20 @@ func (o *Orchestrator) checkNotSchedulable(ctx context.Context) {
 					}
 				}()
 			}
-			time.Sleep(100 * time.Millisecond)
+
+			// the scheduler forgets jobs which were deleted from the catalog, so their tickers stop
+			catalog := o.Catalog.All()
+			for _, u := range o.scheduler.jobs() {
+				if _, found := catalog[u]; !found {
+					go func() {
+						o.chScheduler <- schedulerMessage{
+							uuid:    u,
+							enabled: false,
+						}
+					}()
+				}
+			}
+			o.clock.Sleep(100 * time.Millisecond)
 		}
 	}
 }
@@ -161,15 +312,21 @@ func (o *Orchestrator) checkSchedulable(ctx context.Context) {
 		default:
 			jobs := o.Catalog.GetSchedulable()
 			for _, j := range jobs {
+				msg := schedulerMessage{
+					uuid:     j.Uuid,
+					enabled:  j.Enabled,
+					schedule: j.Schedule,
+					misfire:  j.MisfirePolicy,
+				}
+				if !o.scheduler.tickerExists(j.Uuid) { // missed times are only evaluated when the ticker starts
+					msg.lastTrigger = o.lastTriggerTime(j.Uuid)
+				}
+
 				go func() {
-					o.chScheduler <- schedulerMessage{
-						uuid:     j.Uuid,
-						enabled:  j.Enabled,
-						schedule: j.Schedule,
-					}
+					o.chScheduler <- msg
 				}()
 			}
-			time.Sleep(100 * time.Millisecond)
+			o.clock.Sleep(100 * time.Millisecond)
 		}
 	}
 }
@@ -192,23 +349,96 @@ Exit:
 				if err != nil {
 					retries++
 					o.logger.LogAttrs(ctx, slog.LevelWarn, "failed to get job for dispatcher", slog.String("job", tick.uuid.String()), slog.String("error", err.Error()))
-					time.Sleep(1 * time.Second) // back off from catalog before retrying
+					o.clock.Sleep(1 * time.Second) // back off from catalog before retrying
 					break
 				}
 
+				// the run is reserved in the catalog when it gets a slot, so the run limit of the job is never exceeded
+				r := newRun(j.Uuid, tick.time)
+				r.trigger = tick.trigger
+				if tick.run != uuid.Nil {
+					r.uuid = tick.run
+				}
+				if tick.tasks != nil {
+					j.Tasks = tick.tasks
+				}
+				if tick.trigger == job.TriggerBackfill {
+					j.OverlapPolicy = job.OverlapQueue // backfill runs wait for a slot, instead of being skipped or replacing other runs
+				}
+				switch err = o.runs.acquire(ctx, j, r, func() error { return o.Catalog.ReserveRun(j.Uuid) }); {
+				case errors.Is(err, errRunSkipped):
+					o.logger.LogAttrs(ctx, slog.LevelInfo, "job skipped", slog.String("job", j.Uuid.String()), slog.Int("max_concurrency", j.MaxConcurrency))
+					o.notDispatched(tick, r.uuid, job.StatusSkipped, nil)
+					break Exit
+				case errors.Is(err, errRunCancelled):
+					o.logger.LogAttrs(ctx, slog.LevelInfo, "job cancelled", slog.String("job", j.Uuid.String()), slog.String("instance", r.uuid.String()))
+					o.notDispatched(tick, r.uuid, job.StatusCancelled, err)
+					break Exit
+				case err != nil:
+					o.logger.LogAttrs(ctx, slog.LevelDebug, "job not dispatched", slog.String("job", j.Uuid.String()), slog.String("error", err.Error()))
+					if tick.trigger != job.TriggerSchedule && ctx.Err() == nil {
+						o.notDispatched(tick, r.uuid, job.StatusSkipped, err) // requested runs always have a result
+					}
+					break Exit
+				}
+
 				o.chDispatcher <- dispatcherMessage{
 					job:               j,
 					handlerRepository: o.Handlers,
 					triggerTime:       tick.time,
+					trigger:           tick.trigger,
+					run:               r,
 				}
 			} else {
 				o.logger.LogAttrs(ctx, slog.LevelError, "failed to send job to dispatcher", slog.String("job", tick.uuid.String()), slog.String("error", err.Error()))
+				if tick.trigger != job.TriggerSchedule {
+					o.runs.forget(tick.run)
+					o.notDispatched(tick, tick.run, job.StatusSkipped, err)
+				}
 			}
 			break Exit
 		}
 	}
 }
 
+// notDispatched sends the result with status for the run with uuid runUuid of tick, with err as the reason it was not dispatched.
+func (o *Orchestrator) notDispatched(tick SchedulerTick, runUuid uuid.UUID, status job.Status, err error) {
+	o.chResults <- job.Result{
+		Uuid:        tick.uuid,
+		RunUuid:     runUuid,
+		Status:      status,
+		Trigger:     tick.trigger,
+		TriggerTime: tick.time,
+		Error:       err,
+	}
+}
+
+// lastTriggerTime returns the latest trigger time of the scheduled results for the job in the catalog.
+// Returns the zero time if the job has no results.
+func (o *Orchestrator) lastTriggerTime(uuid uuid.UUID) time.Time {
+	results, err := o.Catalog.GetResults(uuid)
+	if err != nil {
+		return time.Time{}
+	}
+
+	var last time.Time
+	for _, r := range results {
+		if r.Trigger == job.TriggerSchedule && r.TriggerTime.After(last) {
+			last = r.TriggerTime
+		}
+	}
+	return last
+}
+
+// push pushes tick to the queue, and wakes up the queue processor.
+func (o *Orchestrator) push(tick SchedulerTick) {
+	o.queue.Push(tick)
+	select {
+	case o.chQueued <- struct{}{}:
+	default: // the queue processor is woken up already
+	}
+}
+
 func (o *Orchestrator) queueProcessor(ctx context.Context) {
 	o.logger.LogAttrs(ctx, slog.LevelDebug, "starting queue processor")
 	defer o.logger.LogAttrs(ctx, slog.LevelDebug, "stopping queue processor")
@@ -221,8 +451,11 @@ func (o *Orchestrator) queueProcessor(ctx context.Context) {
 			var err error
 			if t, err = o.queue.Pop(); err != nil {
 				// TODO add custom error type to handle different events?
-				// o.logger.LogAttrs(o.ctx, slog.LevelDebug, "no jobs in queue")
-				// time.Sleep(100 * time.Millisecond)
+				// wait until a tick is pushed, instead of polling the empty queue
+				select {
+				case <-ctx.Done():
+				case <-o.chQueued:
+				}
 				break
 			}
 
@@ -239,6 +472,8 @@ func (o *Orchestrator) resultHandler(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case r := <-o.chResults:
+			o.runs.release(r.Uuid, r.RunUuid)
+			o.watchers.notify(r)
 			go o.Catalog.AddResult(r) // make sure results are read from the channel as fast as possible
 		}
 	}
@@ -253,7 +488,7 @@ func (o *Orchestrator) ticksListener(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case t := <-o.chTick:
-			go o.queue.Push(t)
+			go o.push(t)
 		}
 	}
 }
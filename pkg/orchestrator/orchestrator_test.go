@@ -0,0 +1,557 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jantytgat/go-jobs/pkg/cron"
+	"github.com/jantytgat/go-jobs/pkg/job"
+	"github.com/jantytgat/go-jobs/pkg/task"
+)
+
+type orchestratorTestRun struct {
+	value       string
+	triggerTime time.Time // trigger time in the pipeline
+}
+
+type orchestratorTestTask struct {
+	Value string
+	Wait  bool                     // report progress and wait until the task is cancelled
+	ch    chan orchestratorTestRun // receives a run each time the task is executed
+}
+
+func (t orchestratorTestTask) Name() string { return "orchestratorTestTask" }
+func (t orchestratorTestTask) DefaultHandler() task.Handler {
+	return t.Handler(time.Second)
+}
+func (t orchestratorTestTask) DefaultHandlerPool(ctx context.Context) *task.HandlerPool {
+	return t.HandlerPool(ctx, time.Second)
+}
+func (t orchestratorTestTask) Handler(timeout time.Duration) task.Handler {
+	return task.NewHandler(t.Name(), timeout, func(ctx context.Context, t task.Task, p *task.Pipeline) error {
+		if t.(orchestratorTestTask).Wait {
+			p.ReportProgress(50)
+			p.ReportMessage("waiting")
+		}
+		t.(orchestratorTestTask).ch <- orchestratorTestRun{value: t.(orchestratorTestTask).Value, triggerTime: p.TriggerTime()}
+		if t.(orchestratorTestTask).Wait {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+}
+func (t orchestratorTestTask) HandlerPool(ctx context.Context, timeout time.Duration) *task.HandlerPool {
+	return task.NewHandlerPool(ctx, t.Handler(timeout), 1)
+}
+
+func (t orchestratorTestTask) Validate() error {
+	if t.Value == "" {
+		return errors.New("value required")
+	}
+	return nil
+}
+
+type orchestratorOtherTestTask struct {
+	orchestratorTestTask
+}
+
+// newTestOrchestrator returns an orchestrator running on a fake clock, with jobs added to its catalog.
+// When start is true, the orchestrator is started and stopped again when the test finishes.
+func newTestOrchestrator(t *testing.T, maxRunners int, start bool, jobs ...job.Job) (*Orchestrator, *cron.FakeClock) {
+	t.Helper()
+
+	clock := cron.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	o, err := New(slog.New(slog.DiscardHandler), "test", maxRunners, WithClock(clock))
+	if err != nil {
+		t.Fatalf("cannot create orchestrator: %v", err)
+	}
+	for _, j := range jobs {
+		if err = o.Catalog.Add(j); err != nil {
+			t.Fatalf("cannot add job: %v", err)
+		}
+	}
+
+	if start {
+		if err = o.Start(t.Context()); err != nil {
+			t.Fatalf("cannot start orchestrator: %v", err)
+		}
+		t.Cleanup(o.Stop)
+	}
+	return o, clock
+}
+
+// waitForResults waits until the catalog has at least n results for job uuid, and returns them.
+func waitForResults(t *testing.T, o *Orchestrator, uuid uuid.UUID, n int) []job.Result {
+	t.Helper()
+
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		if results, _ := o.Catalog.GetResults(uuid); len(results) >= n {
+			return results
+		}
+	}
+	t.Fatalf("job %s should have %d results", uuid, n)
+	return nil
+}
+
+// advanceUntilRun advances clock a second at a time until a run is received on ch.
+func advanceUntilRun(t *testing.T, clock *cron.FakeClock, ch chan orchestratorTestRun) orchestratorTestRun {
+	t.Helper()
+
+	for start := time.Now(); time.Since(start) < 5*time.Second; {
+		select {
+		case r := <-ch:
+			return r
+		case <-time.After(10 * time.Millisecond):
+			clock.Advance(time.Second)
+		}
+	}
+	t.Fatalf("scheduled job should run")
+	return orchestratorTestRun{}
+}
+
+func TestOrchestrator_NeverDue(t *testing.T) {
+	never, err := cron.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("cannot parse schedule: %v", err)
+	}
+
+	ch := make(chan orchestratorTestRun, 10)
+	neverDue := job.New(uuid.New(), "never", never, []task.Task{orchestratorTestTask{Value: "never", ch: ch}})
+	everySecond := job.New(uuid.New(), "every second", cron.EverySecond(), []task.Task{orchestratorTestTask{Value: "every second", ch: ch}})
+	o, clock := newTestOrchestrator(t, 1, true, neverDue, everySecond)
+
+	// the ticker of the job which is never due must not block the scheduler
+	for range 3 {
+		if r := advanceUntilRun(t, clock, ch); r.value != everySecond.Tasks[0].(orchestratorTestTask).Value {
+			t.Errorf("only the job which is due every second should run, received %s", r.value)
+		}
+	}
+	if o.scheduler.tickerExists(neverDue.Uuid) {
+		t.Errorf("scheduler should not start a ticker for a job which is never due")
+	}
+}
+
+func TestOrchestrator_Once(t *testing.T) {
+	ch := make(chan orchestratorTestRun, 10)
+	at := time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC) // an hour before the orchestrator starts
+	past := job.New(uuid.New(), "past", cron.NewOnce(at), []task.Task{orchestratorTestTask{Value: "past", ch: ch}})
+	fired := job.New(uuid.New(), "fired", cron.NewOnce(at), []task.Task{orchestratorTestTask{Value: "fired", ch: ch}})
+	recurring := job.New(uuid.New(), "recurring", cron.EverySecond(), []task.Task{orchestratorTestTask{Value: "recurring", ch: ch}})
+
+	clock := cron.NewFakeClock(at.Add(time.Hour))
+	o, err := New(slog.New(slog.DiscardHandler), "test", 1, WithClock(clock))
+	if err != nil {
+		t.Fatalf("cannot create orchestrator: %v", err)
+	}
+	for _, j := range []job.Job{past, fired, recurring} {
+		if err = o.Catalog.Add(j); err != nil {
+			t.Fatalf("cannot add job: %v", err)
+		}
+	}
+	o.Catalog.AddResult(job.Result{Uuid: fired.Uuid, RunUuid: uuid.New(), Status: job.StatusSuccess, Trigger: job.TriggerSchedule, TriggerTime: at})
+
+	if err = o.Start(t.Context()); err != nil {
+		t.Fatalf("cannot start orchestrator: %v", err)
+	}
+	t.Cleanup(o.Stop)
+
+	// the jobs which will not be due anymore must not block the recurring job
+	for range 3 {
+		if r := advanceUntilRun(t, clock, ch); r.value != "recurring" {
+			t.Errorf("only the recurring job should run, received %s", r.value)
+		}
+	}
+
+	for _, j := range []job.Job{past, fired} {
+		if o.scheduler.tickerExists(j.Uuid) {
+			t.Errorf("scheduler should not start a ticker for finished job %s", j.Name)
+		}
+	}
+	if results, _ := o.Catalog.GetResults(past.Uuid); len(results) != 0 {
+		t.Errorf("past job should not run, received %+v", results)
+	}
+	if results, _ := o.Catalog.GetResults(fired.Uuid); len(results) != 1 {
+		t.Errorf("fired job should not run again, received %+v", results)
+	}
+}
+
+func TestOrchestrator_DeletedJob(t *testing.T) {
+	ch := make(chan orchestratorTestRun, 10)
+	j := job.New(uuid.New(), "deleted", cron.EverySecond(), []task.Task{orchestratorTestTask{Value: "deleted", ch: ch}})
+	o, clock := newTestOrchestrator(t, 1, true, j)
+	advanceUntilRun(t, clock, ch)
+
+	// the loader deletes a job from the catalog when its file is removed
+	if err := o.Catalog.Delete(j.Uuid); err != nil {
+		t.Fatalf("cannot delete job: %v", err)
+	}
+	for start := time.Now(); o.scheduler.tickerExists(j.Uuid); time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("scheduler should stop the ticker of a deleted job")
+		}
+		clock.Advance(100 * time.Millisecond)
+	}
+
+	// runs which were queued before the job was deleted might still finish
+	for range 10 {
+		clock.Advance(100 * time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+	}
+	for len(ch) > 0 {
+		<-ch
+	}
+
+	for range 3 {
+		clock.Advance(time.Second)
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(ch) > 0 {
+		t.Errorf("deleted job should not run anymore")
+	}
+}
+
+func TestOrchestrator_Reenabled(t *testing.T) {
+	schedule, err := cron.Parse("0 * * * * *")
+	if err != nil {
+		t.Fatalf("cannot parse schedule: %v", err)
+	}
+
+	ch := make(chan orchestratorTestRun, 20)
+	j := job.New(uuid.New(), "reenabled", schedule, []task.Task{orchestratorTestTask{Value: "reenabled", ch: ch}}, job.WithMisfirePolicy(cron.FireAll(10, 0)))
+	o, clock := newTestOrchestrator(t, 1, true, j)
+	advanceUntilRun(t, clock, ch)
+	waitForResults(t, o, j.Uuid, 1)
+
+	setEnabled := func(enabled bool) {
+		j.Enabled = enabled
+		if err = o.Catalog.Update(j); err != nil {
+			t.Fatalf("cannot update job: %v", err)
+		}
+		for start := time.Now(); o.scheduler.tickerExists(j.Uuid) != enabled; time.Sleep(10 * time.Millisecond) {
+			if time.Since(start) > 5*time.Second {
+				t.Fatalf("scheduler should update the ticker when the job is enabled: %t", enabled)
+			}
+			clock.Advance(100 * time.Millisecond)
+		}
+	}
+
+	// the times during which the job was disabled are not missed, so they are not fired when the job is enabled again
+	setEnabled(false)
+	clock.Advance(10 * time.Minute)
+	setEnabled(true)
+
+	time.Sleep(100 * time.Millisecond)
+	if len(ch) > 0 {
+		t.Fatalf("re-enabled job should not fire the times during which it was disabled, received %d runs", len(ch))
+	}
+	if r := advanceUntilRun(t, clock, ch); !r.triggerTime.Equal(time.Date(2025, 1, 1, 0, 12, 0, 0, time.UTC)) {
+		t.Errorf("re-enabled job should run at its next time, received a run for %s", r.triggerTime)
+	}
+}
+
+func TestOrchestrator_Trigger(t *testing.T) {
+	ch := make(chan orchestratorTestRun, 10)
+	j := job.New(uuid.New(), "trigger", cron.EverySecond(), []task.Task{orchestratorTestTask{Value: "scheduled", ch: ch}}, job.WithDisabled())
+	completed := job.New(uuid.New(), "completed", cron.EverySecond(), []task.Task{orchestratorTestTask{Value: "scheduled", ch: ch}}, job.WithRunLimit(1), job.WithDisabled())
+	o, clock := newTestOrchestrator(t, 1, true, j, completed)
+	if err := o.Catalog.ReserveRun(completed.Uuid); err != nil {
+		t.Fatalf("cannot reserve run: %v", err)
+	}
+
+	var tests = []struct {
+		name    string
+		job     uuid.UUID
+		opts    []TriggerOption
+		wanted  string // value of the task which runs
+		wantErr bool
+	}{
+		{name: "job", job: j.Uuid, wanted: "scheduled"},
+		{name: "override", job: j.Uuid, opts: []TriggerOption{WithTaskOverride(0, orchestratorTestTask{Value: "override", ch: ch})}, wanted: "override"},
+		{name: "params", job: j.Uuid, opts: []TriggerOption{WithTaskParams(0, task.JSONSpec([]byte(`{"Value": "params"}`)))}, wanted: "params"},
+		{name: "unknown job", job: uuid.New(), wantErr: true},
+		{name: "completed job", job: completed.Uuid, wantErr: true},
+		{name: "override out of range", job: j.Uuid, opts: []TriggerOption{WithTaskOverride(1, orchestratorTestTask{Value: "override"})}, wantErr: true},
+		{name: "override type", job: j.Uuid, opts: []TriggerOption{WithTaskOverride(0, orchestratorOtherTestTask{})}, wantErr: true},
+		{name: "params out of range", job: j.Uuid, opts: []TriggerOption{WithTaskParams(-1, task.JSONSpec(nil))}, wantErr: true},
+		{name: "params invalid", job: j.Uuid, opts: []TriggerOption{WithTaskParams(0, task.JSONSpec([]byte(`{"Value": ""}`)))}, wantErr: true},
+	}
+
+	var runs int
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runUuid, err := o.Trigger(t.Context(), tt.job, tt.opts...)
+			if tt.wantErr {
+				if err == nil || runUuid != uuid.Nil {
+					t.Errorf("trigger should return an error, received run %s", runUuid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cannot trigger job: %v", err)
+			}
+
+			select {
+			case r := <-ch:
+				if r.value != tt.wanted || !r.triggerTime.Equal(clock.Now()) {
+					t.Errorf("triggered run should execute task %s at %s, received %+v", tt.wanted, clock.Now(), r)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("triggered run should execute")
+			}
+
+			// the run must finish before the next trigger, otherwise the next run is skipped
+			runs++
+			results := waitForResults(t, o, tt.job, runs)
+			if !slices.ContainsFunc(results, func(r job.Result) bool { return r.RunUuid == runUuid && r.Trigger == job.TriggerManual }) {
+				t.Errorf("job should have a manual result for run %s, received %+v", runUuid, results)
+			}
+		})
+	}
+
+	if v := j.Tasks[0].(orchestratorTestTask).Value; v != "scheduled" {
+		t.Errorf("trigger should not change the tasks of the job, received %s", v)
+	}
+	if len(ch) > 0 {
+		t.Errorf("invalid triggers should not run")
+	}
+}
+
+func TestOrchestrator_TriggerNotRunning(t *testing.T) {
+	j := job.New(uuid.New(), "trigger", cron.EverySecond(), []task.Task{orchestratorTestTask{Value: "scheduled"}}, job.WithDisabled())
+	o, _ := newTestOrchestrator(t, 1, false, j)
+
+	if _, err := o.Trigger(t.Context(), j.Uuid); err == nil {
+		t.Errorf("trigger should return an error when the orchestrator is not running")
+	}
+	if o.queue.Length() != 0 {
+		t.Errorf("trigger should not be queued when the orchestrator is not running")
+	}
+}
+
+func TestOrchestrator_TriggerRun(t *testing.T) {
+	ch := make(chan orchestratorTestRun, 10)
+	j := job.New(uuid.New(), "trigger", cron.EverySecond(), []task.Task{orchestratorTestTask{Value: "scheduled", ch: ch}}, job.WithDisabled())
+	o, _ := newTestOrchestrator(t, 1, true, j)
+
+	runUuid, err := o.Trigger(t.Context(), j.Uuid, WithTaskOverride(0, orchestratorTestTask{Value: "manual", ch: ch}))
+	if err != nil {
+		t.Fatalf("cannot trigger job: %v", err)
+	}
+
+	select {
+	case r := <-ch:
+		if r.value != "manual" {
+			t.Errorf("triggered run should execute the overridden task, received %s", r.value)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("triggered run should execute")
+	}
+
+	if r := waitForResults(t, o, j.Uuid, 1)[0]; r.RunUuid != runUuid || r.Trigger != job.TriggerManual || r.Status != job.StatusSuccess {
+		t.Errorf("invalid result for run %s: %+v", runUuid, r)
+	}
+}
+
+func TestOrchestrator_Backfill(t *testing.T) {
+	schedule, err := cron.Parse("0 0 0 * * *")
+	if err != nil {
+		t.Fatalf("cannot parse schedule: %v", err)
+	}
+	ch := make(chan orchestratorTestRun, 10)
+	j := job.New(uuid.New(), "backfill", schedule, []task.Task{orchestratorTestTask{Value: "backfill", ch: ch}}, job.WithDisabled())
+	o, _ := newTestOrchestrator(t, 2, false, j)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	if _, err = o.Backfill(j.Uuid, from, to, 2); err == nil {
+		t.Errorf("backfill should return an error when the orchestrator is not running")
+	}
+
+	if err = o.Start(t.Context()); err != nil {
+		t.Fatalf("cannot start orchestrator: %v", err)
+	}
+	t.Cleanup(o.Stop)
+
+	var errTests = []struct {
+		name        string
+		job         uuid.UUID
+		from        time.Time
+		to          time.Time
+		parallelism int
+	}{
+		{name: "unknown job", job: uuid.New(), from: from, to: to, parallelism: 1},
+		{name: "empty range", job: j.Uuid, from: to, to: from, parallelism: 1},
+		{name: "parallelism", job: j.Uuid, from: from, to: to, parallelism: 0},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := o.Backfill(tt.job, tt.from, tt.to, tt.parallelism); err == nil {
+				t.Errorf("backfill should return an error")
+			}
+		})
+	}
+
+	b, err := o.Backfill(j.Uuid, from, to, 2)
+	if err != nil {
+		t.Fatalf("cannot backfill job: %v", err)
+	}
+
+	// the job runs once at a time, so the backfill runs wait for each other instead of being skipped
+	var triggerTimes []time.Time
+	for range 7 {
+		select {
+		case r := <-ch:
+			triggerTimes = append(triggerTimes, r.triggerTime)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("backfill should run the job for each fire time, received %v", triggerTimes)
+		}
+	}
+	select {
+	case <-b.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("backfill should finish")
+	}
+
+	if err = b.Err(); err != nil {
+		t.Errorf("backfill should finish without error, received %v", err)
+	}
+	if p := b.Progress(); p != (BackfillProgress{Total: 7, Queued: 7, Succeeded: 7}) {
+		t.Errorf("invalid progress %+v", p)
+	}
+
+	slices.SortFunc(triggerTimes, time.Time.Compare)
+	for i, tt := range triggerTimes {
+		if wanted := from.AddDate(0, 0, i); !tt.Equal(wanted) {
+			t.Errorf("run %d should see trigger time %s in the pipeline, received %s", i, wanted, tt)
+		}
+	}
+
+	for _, r := range waitForResults(t, o, j.Uuid, 7) {
+		if r.Trigger != job.TriggerBackfill || r.Status != job.StatusSuccess {
+			t.Errorf("invalid result for backfill run %s: %+v", r.RunUuid, r)
+		}
+	}
+}
+
+func TestOrchestrator_BackfillCancel(t *testing.T) {
+	ch := make(chan orchestratorTestRun, 10)
+	j := job.New(uuid.New(), "backfill", cron.EverySecond(), []task.Task{orchestratorTestTask{Value: "backfill", Wait: true, ch: ch}}, job.WithDisabled())
+	o, _ := newTestOrchestrator(t, 1, true, j)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b, err := o.Backfill(j.Uuid, from, from.Add(time.Hour), 1)
+	if err != nil {
+		t.Fatalf("cannot backfill job: %v", err)
+	}
+
+	// the first run waits until it is cancelled, so no other runs are queued
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("backfill should run the job")
+	}
+	b.Cancel()
+
+	select {
+	case <-b.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("cancelled backfill should finish")
+	}
+
+	if err = b.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled backfill should return context.Canceled, received %v", err)
+	}
+	if p := b.Progress(); p != (BackfillProgress{Total: 3600, Queued: 1, Failed: 1}) {
+		t.Errorf("cancelled backfill should stop queuing and cancel the queued runs, received %+v", p)
+	}
+}
+
+func TestOrchestrator_CancelRun(t *testing.T) {
+	ch := make(chan orchestratorTestRun, 10)
+	j := job.New(uuid.New(), "cancel", cron.EverySecond(), []task.Task{orchestratorTestTask{Value: "cancel", Wait: true, ch: ch}}, job.WithDisabled())
+	o, _ := newTestOrchestrator(t, 1, false, j)
+
+	if err := o.CancelRun(uuid.New()); !errors.Is(err, ErrRunNotFound) {
+		t.Errorf("cancelling an unknown run should return ErrRunNotFound, received %v", err)
+	}
+
+	if err := o.Start(t.Context()); err != nil {
+		t.Fatalf("cannot start orchestrator: %v", err)
+	}
+	t.Cleanup(o.Stop)
+
+	runUuid, err := o.Trigger(t.Context(), j.Uuid)
+	if err != nil {
+		t.Fatalf("cannot trigger job: %v", err)
+	}
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("triggered run should execute")
+	}
+
+	if err = o.CancelRun(runUuid); err != nil {
+		t.Fatalf("cannot cancel run: %v", err)
+	}
+
+	// the task returns as soon as it is cancelled, long before the timeout of its handler
+	r := waitForResults(t, o, j.Uuid, 1)[0]
+	if r.RunUuid != runUuid || r.Status != job.StatusCancelled || r.RunTime > 500*time.Millisecond {
+		t.Errorf("invalid result for cancelled run %s: %+v", runUuid, r)
+	}
+	if len(r.TaskResults) != 1 || r.TaskResults[0].Status != task.StatusCanceled {
+		t.Errorf("task of the cancelled run should have status %s, received %+v", task.StatusCanceled, r.TaskResults)
+	}
+}
+
+func TestOrchestrator_ActiveRuns(t *testing.T) {
+	ch := make(chan orchestratorTestRun, 10)
+	j := job.New(uuid.New(), "active", cron.EverySecond(), []task.Task{orchestratorTestTask{Value: "first", ch: ch}, orchestratorTestTask{Value: "second", Wait: true, ch: ch}}, job.WithDisabled())
+	o, clock := newTestOrchestrator(t, 1, true, j)
+
+	if runs := o.ActiveRuns(); len(runs) != 0 {
+		t.Errorf("orchestrator should have no active runs, received %+v", runs)
+	}
+
+	runUuid, err := o.Trigger(t.Context(), j.Uuid)
+	if err != nil {
+		t.Fatalf("cannot trigger job: %v", err)
+	}
+	for range 2 {
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("triggered run should execute its tasks")
+		}
+	}
+
+	start := clock.Now()
+	clock.Advance(3 * time.Second)
+	runs := o.Statistics().ActiveRuns
+	if len(runs) != 1 {
+		t.Fatalf("orchestrator should have 1 active run, received %+v", runs)
+	}
+	r := runs[0]
+	if r.JobUuid != j.Uuid || r.RunUuid != runUuid || r.Trigger != job.TriggerManual || !r.StartTime.Equal(start) || r.Elapsed != 3*time.Second {
+		t.Errorf("invalid active run %+v", r)
+	}
+	if wanted := (task.Progress{TaskIndex: 1, TaskName: "orchestratorTestTask", Percent: 50, Message: "waiting"}); r.Progress != wanted {
+		t.Errorf("invalid progress: got %+v expected %+v", r.Progress, wanted)
+	}
+
+	if err = o.CancelRun(runUuid); err != nil {
+		t.Fatalf("cannot cancel run: %v", err)
+	}
+	for start := time.Now(); len(o.ActiveRuns()) > 0; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("finished run should not be active")
+		}
+	}
+}
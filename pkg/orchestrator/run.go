@@ -0,0 +1,105 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jantytgat/go-jobs/pkg/job"
+	"github.com/jantytgat/go-jobs/pkg/task"
+)
+
+// errRunReplaced is the cause of the cancellation of a run which is replaced by a new run of the same job.
+var errRunReplaced = errors.New("run replaced by a new trigger")
+
+// errRunCancelled is the cause of the cancellation of a run by Orchestrator.CancelRun.
+var errRunCancelled = errors.New("run cancelled by user")
+
+func newRun(jobUuid uuid.UUID, triggerTime time.Time) *run {
+	return &run{
+		uuid:        uuid.New(),
+		job:         jobUuid,
+		triggerTime: triggerTime,
+	}
+}
+
+// run is a single execution of a job, from the moment it is dispatched until its result is handled.
+type run struct {
+	uuid        uuid.UUID
+	job         uuid.UUID
+	trigger     job.Trigger
+	triggerTime time.Time
+	startTime   time.Time               // zero until the run starts
+	progress    task.Progress           // current task of the run and its progress
+	cause       error                   // cause of the cancellation of the run, nil if it was not stopped
+	cancel      context.CancelCauseFunc // cancels the run after it started
+	mux         sync.Mutex
+}
+
+// start returns the context of the run, derived from ctx, and marks the run as started at startTime.
+// If the run was stopped before it started, the context is cancelled already.
+func (r *run) start(ctx context.Context, startTime time.Time) (context.Context, context.CancelCauseFunc) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.startTime = startTime
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	r.cancel = cancel
+	if r.cause != nil {
+		cancel(r.cause)
+	}
+	return runCtx, cancel
+}
+
+// stop cancels the run with cause, or makes sure it is cancelled as soon as it starts.
+func (r *run) stop(cause error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.cause == nil {
+		r.cause = cause
+	}
+	if r.cancel != nil {
+		r.cancel(cause)
+	}
+}
+
+// stopping returns true if the run was stopped, but it might still be running.
+func (r *run) stopping() bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	return r.cause != nil
+}
+
+// setProgress stores the progress of the tasks of the run, see task.WithPipelineProgress.
+func (r *run) setProgress(p task.Progress) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.progress = p
+}
+
+// snapshot returns the state of the run at now.
+// Returns false if the run did not start yet.
+func (r *run) snapshot(now time.Time) (ActiveRun, bool) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.startTime.IsZero() {
+		return ActiveRun{}, false
+	}
+	return ActiveRun{
+		JobUuid:     r.job,
+		RunUuid:     r.uuid,
+		Trigger:     r.trigger,
+		TriggerTime: r.triggerTime,
+		StartTime:   r.startTime,
+		Elapsed:     now.Sub(r.startTime),
+		Progress:    r.progress,
+	}, true
+}
@@ -0,0 +1,259 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jantytgat/go-jobs/pkg/job"
+)
+
+// ErrRunNotFound is returned when a run is not queued, waiting or running, for example because it finished already.
+var ErrRunNotFound = errors.New("run not found")
+
+// errRunSkipped is returned by runRegistry.acquire when a run is skipped, because the job is running MaxConcurrency times.
+var errRunSkipped = errors.New("run skipped")
+
+func newRunRegistry() *runRegistry {
+	return &runRegistry{
+		runs:    make(map[uuid.UUID][]*run),
+		index:   make(map[uuid.UUID]*run),
+		waiting: make(map[uuid.UUID][]*runWaiter),
+		queued:  make(map[uuid.UUID]bool),
+	}
+}
+
+// runRegistry holds the runs which are dispatched and not finished yet, by job and by run.
+// It enforces the concurrency limit of jobs, using their overlap policy for runs exceeding the limit.
+type runRegistry struct {
+	runs    map[uuid.UUID][]*run       // active runs by job, oldest first
+	index   map[uuid.UUID]*run         // active runs by run
+	waiting map[uuid.UUID][]*runWaiter // runs waiting for an active run to finish by job, in order of arrival
+	queued  map[uuid.UUID]bool         // requested runs in the queue by run, true if the run was cancelled in the queue
+	mux     sync.Mutex
+}
+
+// runWaiter is a run waiting for a slot, which closes ready when the run becomes active or cannot be reserved.
+type runWaiter struct {
+	run       *run
+	reserve   func() error
+	replacing bool  // the run waits for a run which it replaced to stop
+	err       error // error returned by reserve, or the cause of the cancellation of the run
+	ready     chan struct{}
+}
+
+// acquire makes run r of job j active, according to the concurrency limit and overlap policy of the job.
+// Function reserve is called when the run gets a slot, before it becomes active or replaces another run.
+// If reserve returns an error, for example because the run limit of the job is reached, the run does not become active.
+// A run which replaces another run waits until the replaced run is released, so the job never exceeds its concurrency limit.
+//
+// Returns errRunSkipped if the run must be skipped, the error of reserve, the cause of the cancellation of the run,
+// or the error of ctx if it is done while the run waits for a slot.
+func (rr *runRegistry) acquire(ctx context.Context, j job.Job, r *run, reserve func() error) error {
+	rr.mux.Lock()
+
+	if cancelled, ok := rr.queued[r.uuid]; ok {
+		delete(rr.queued, r.uuid)
+		if cancelled {
+			rr.mux.Unlock()
+			return errRunCancelled
+		}
+	}
+
+	active := rr.runs[j.Uuid]
+	if !j.LimitConcurrency || len(active) < max(j.MaxConcurrency, 1) {
+		defer rr.mux.Unlock()
+
+		if err := reserve(); err != nil {
+			return err
+		}
+		rr.runs[j.Uuid] = append(active, r)
+		rr.index[r.uuid] = r
+		return nil
+	}
+
+	switch j.OverlapPolicy {
+	case job.OverlapReplace:
+		if err := reserve(); err != nil {
+			rr.mux.Unlock()
+			return err
+		}
+
+		// Stop the oldest run which is not stopping yet, looking at the active runs and then at the runs waiting to replace another run.
+		// A replaced run keeps its slot until it is released, the runs replacing it wait for a slot before the other waiting runs.
+		waiting := rr.waiting[j.Uuid]
+		replacing := slices.IndexFunc(waiting, func(w *runWaiter) bool { return !w.replacing })
+		if replacing < 0 {
+			replacing = len(waiting)
+		}
+		candidates := slices.Clone(active)
+		for _, w := range waiting[:replacing] {
+			candidates = append(candidates, w.run)
+		}
+		if i := slices.IndexFunc(candidates, func(c *run) bool { return !c.stopping() }); i >= 0 {
+			candidates[i].stop(errRunReplaced)
+		}
+
+		w := &runWaiter{run: r, reserve: func() error { return nil }, replacing: true, ready: make(chan struct{})}
+		rr.waiting[j.Uuid] = slices.Insert(waiting, replacing, w)
+		return rr.wait(ctx, j.Uuid, w)
+	case job.OverlapQueue:
+		w := &runWaiter{run: r, reserve: reserve, ready: make(chan struct{})}
+		rr.waiting[j.Uuid] = append(rr.waiting[j.Uuid], w)
+		return rr.wait(ctx, j.Uuid, w)
+	default:
+		rr.mux.Unlock()
+		return errRunSkipped
+	}
+}
+
+// wait waits until waiting run w of job jobUuid becomes active, cannot be reserved or is cancelled, or until ctx is done.
+// The caller must hold the lock, which is released while waiting.
+func (rr *runRegistry) wait(ctx context.Context, jobUuid uuid.UUID, w *runWaiter) error {
+	rr.mux.Unlock()
+
+	select {
+	case <-w.ready:
+		return w.err
+	case <-ctx.Done():
+		rr.mux.Lock()
+		defer rr.mux.Unlock()
+
+		if i := slices.Index(rr.waiting[jobUuid], w); i >= 0 {
+			rr.waiting[jobUuid] = slices.Delete(rr.waiting[jobUuid], i, i+1)
+			if len(rr.waiting[jobUuid]) == 0 {
+				delete(rr.waiting, jobUuid)
+			}
+			return ctx.Err()
+		}
+		if w.err == nil {
+			rr.remove(jobUuid, w.run.uuid) // the run became active while ctx was done
+		}
+		return ctx.Err()
+	}
+}
+
+// release removes the run with uuid runUuid of job jobUuid, so the first waiting run of the job can become active.
+func (rr *runRegistry) release(jobUuid uuid.UUID, runUuid uuid.UUID) {
+	rr.mux.Lock()
+	defer rr.mux.Unlock()
+
+	rr.remove(jobUuid, runUuid)
+}
+
+// remove removes an active run and activates the first waiting run of the job which can be reserved.
+// The caller must hold the lock.
+func (rr *runRegistry) remove(jobUuid uuid.UUID, runUuid uuid.UUID) {
+	active := rr.runs[jobUuid]
+	i := slices.IndexFunc(active, func(r *run) bool { return r.uuid == runUuid })
+	if i < 0 { // the run is not active
+		return
+	}
+	active = slices.Delete(active, i, i+1)
+	delete(rr.index, runUuid)
+
+	for len(rr.waiting[jobUuid]) > 0 {
+		w := rr.waiting[jobUuid][0]
+		rr.waiting[jobUuid] = rr.waiting[jobUuid][1:]
+
+		w.err = w.reserve()
+		close(w.ready)
+		if w.err == nil {
+			active = append(active, w.run)
+			rr.index[w.run.uuid] = w.run
+			break
+		}
+	}
+	if len(rr.waiting[jobUuid]) == 0 {
+		delete(rr.waiting, jobUuid)
+	}
+
+	if len(active) == 0 {
+		delete(rr.runs, jobUuid)
+		return
+	}
+	rr.runs[jobUuid] = active
+}
+
+// queue registers a requested run which is pushed to the queue, so it can be cancelled before it is dispatched.
+func (rr *runRegistry) queue(runUuid uuid.UUID) {
+	rr.mux.Lock()
+	defer rr.mux.Unlock()
+
+	rr.queued[runUuid] = false
+}
+
+// forget removes a requested run which left the queue, but cannot be dispatched.
+func (rr *runRegistry) forget(runUuid uuid.UUID) {
+	rr.mux.Lock()
+	defer rr.mux.Unlock()
+
+	delete(rr.queued, runUuid)
+}
+
+// cancel stops the run with uuid runUuid with cause.
+// An active run is cancelled while it runs, a waiting run or a run in the queue makes acquire return cause.
+// Returns ErrRunNotFound if the run is not active, waiting or queued.
+func (rr *runRegistry) cancel(runUuid uuid.UUID, cause error) error {
+	rr.mux.Lock()
+	defer rr.mux.Unlock()
+
+	if r, ok := rr.index[runUuid]; ok {
+		r.stop(cause)
+		return nil
+	}
+
+	for jobUuid, waiting := range rr.waiting {
+		i := slices.IndexFunc(waiting, func(w *runWaiter) bool { return w.run.uuid == runUuid })
+		if i < 0 {
+			continue
+		}
+
+		w := waiting[i]
+		rr.waiting[jobUuid] = slices.Delete(waiting, i, i+1)
+		if len(rr.waiting[jobUuid]) == 0 {
+			delete(rr.waiting, jobUuid)
+		}
+		w.err = cause
+		close(w.ready)
+		return nil
+	}
+
+	if _, ok := rr.queued[runUuid]; ok {
+		rr.queued[runUuid] = true
+		return nil
+	}
+	return ErrRunNotFound
+}
+
+// running returns a snapshot at now of the active runs which started, in order of their start time.
+func (rr *runRegistry) running(now time.Time) []ActiveRun {
+	rr.mux.Lock()
+	defer rr.mux.Unlock()
+
+	runs := make([]ActiveRun, 0, len(rr.index))
+	for _, r := range rr.index {
+		if ar, ok := r.snapshot(now); ok {
+			runs = append(runs, ar)
+		}
+	}
+	slices.SortFunc(runs, func(a, b ActiveRun) int {
+		if c := a.StartTime.Compare(b.StartTime); c != 0 {
+			return c
+		}
+		return strings.Compare(a.RunUuid.String(), b.RunUuid.String())
+	})
+	return runs
+}
+
+// active returns the number of active runs of the job.
+func (rr *runRegistry) active(jobUuid uuid.UUID) int {
+	rr.mux.Lock()
+	defer rr.mux.Unlock()
+	return len(rr.runs[jobUuid])
+}
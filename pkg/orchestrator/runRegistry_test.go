@@ -0,0 +1,343 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jantytgat/go-jobs/pkg/cron"
+	"github.com/jantytgat/go-jobs/pkg/job"
+	"github.com/jantytgat/go-jobs/pkg/task"
+)
+
+// reserve is the reservation for runs of jobs without a run limit.
+func reserve() error {
+	return nil
+}
+
+func TestRunRegistry_Acquire(t *testing.T) {
+	var tests = []struct {
+		name   string
+		opts   []job.Option
+		wanted []bool
+	}{
+		{name: "unlimited", opts: []job.Option{func(j *job.Job) { j.LimitConcurrency = false }}, wanted: []bool{true, true, true}},
+		{name: "skip", opts: []job.Option{job.WithConcurrencyLimit(2)}, wanted: []bool{true, true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := newRunRegistry()
+			j := job.New(uuid.New(), tt.name, cron.EverySecond(), nil, tt.opts...)
+
+			for i, wanted := range tt.wanted {
+				if acquired := rr.acquire(context.Background(), j, newRun(j.Uuid, time.Now()), reserve) == nil; acquired != wanted {
+					t.Errorf("run %d should be acquired: %t, received %t", i, wanted, acquired)
+				}
+			}
+		})
+	}
+}
+
+func TestRunRegistry_Replace(t *testing.T) {
+	rr := newRunRegistry()
+	j := job.New(uuid.New(), "replace", cron.EverySecond(), nil, job.WithOverlapPolicy(job.OverlapReplace))
+
+	first := newRun(j.Uuid, time.Now())
+	rr.acquire(context.Background(), j, first, reserve)
+	ctx, cancel := first.start(context.Background(), time.Now())
+	defer cancel(nil)
+
+	chAcquired := make(chan *run, 2)
+	replacing := []*run{newRun(j.Uuid, time.Now()), newRun(j.Uuid, time.Now())}
+	for _, r := range replacing {
+		go func() {
+			if rr.acquire(context.Background(), j, r, reserve) == nil {
+				chAcquired <- r
+			}
+		}()
+
+		// wait until the run is waiting, so the order of arrival is known
+		for {
+			rr.mux.Lock()
+			queued := rr.waiting[j.Uuid]
+			last := len(queued) > 0 && queued[len(queued)-1].run == r
+			rr.mux.Unlock()
+			if last {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// the replaced run keeps its slot until its result is handled, even if its handler ignores the cancellation
+	if !errors.Is(context.Cause(ctx), errRunReplaced) {
+		t.Errorf("replaced run should be cancelled with errRunReplaced, received %v", context.Cause(ctx))
+	}
+	if rr.active(j.Uuid) != 1 {
+		t.Errorf("job should have 1 active run, received %d", rr.active(j.Uuid))
+	}
+	select {
+	case <-chAcquired:
+		t.Fatalf("replacing run should wait until the replaced run is released")
+	default:
+	}
+
+	// a run which is replaced before it starts is cancelled as soon as it starts
+	previous := first
+	for i, wanted := range replacing {
+		rr.release(j.Uuid, previous.uuid)
+		select {
+		case r := <-chAcquired:
+			if r != wanted {
+				t.Errorf("replacing runs should become active in order of arrival")
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("replacing run should become active after the replaced run is released")
+		}
+		if rr.active(j.Uuid) != 1 {
+			t.Errorf("job should have 1 active run, received %d", rr.active(j.Uuid))
+		}
+
+		c, cancel := wanted.start(context.Background(), time.Now())
+		defer cancel(nil)
+		if replaced := errors.Is(context.Cause(c), errRunReplaced); replaced != (i == 0) {
+			t.Errorf("run %d should be replaced: %t, received cause %v", i, i == 0, context.Cause(c))
+		}
+		previous = wanted
+	}
+}
+
+func TestRunRegistry_Queue(t *testing.T) {
+	rr := newRunRegistry()
+	j := job.New(uuid.New(), "queue", cron.EverySecond(), nil, job.WithOverlapPolicy(job.OverlapQueue))
+
+	first := newRun(j.Uuid, time.Now())
+	rr.acquire(context.Background(), j, first, reserve)
+
+	chAcquired := make(chan *run, 2)
+	waiting := []*run{newRun(j.Uuid, time.Now()), newRun(j.Uuid, time.Now())}
+	for _, r := range waiting {
+		go func() {
+			if rr.acquire(context.Background(), j, r, reserve) == nil {
+				chAcquired <- r
+			}
+		}()
+
+		// wait until the run is queued, so the order of arrival is known
+		for {
+			rr.mux.Lock()
+			queued := rr.waiting[j.Uuid]
+			last := len(queued) > 0 && queued[len(queued)-1].run == r
+			rr.mux.Unlock()
+			if last {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	previous := first
+	for _, wanted := range waiting {
+		select {
+		case r := <-chAcquired:
+			t.Fatalf("run %s should wait for the active run", r.uuid)
+		default:
+		}
+
+		rr.release(j.Uuid, previous.uuid)
+		select {
+		case r := <-chAcquired:
+			if r != wanted {
+				t.Errorf("runs should become active in order of arrival")
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("waiting run should become active after the active run is released")
+		}
+		previous = wanted
+	}
+}
+
+func TestRunRegistry_QueueCancel(t *testing.T) {
+	rr := newRunRegistry()
+	j := job.New(uuid.New(), "queue", cron.EverySecond(), nil, job.WithOverlapPolicy(job.OverlapQueue))
+	rr.acquire(context.Background(), j, newRun(j.Uuid, time.Now()), reserve)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := rr.acquire(ctx, j, newRun(j.Uuid, time.Now()), reserve); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("waiting run should not become active when the context is done")
+	}
+
+	rr.mux.Lock()
+	defer rr.mux.Unlock()
+	if len(rr.waiting[j.Uuid]) != 0 {
+		t.Errorf("cancelled run should be removed from the waiting runs")
+	}
+}
+
+func TestRunRegistry_Reserve(t *testing.T) {
+	errReserve := errors.New("reserve")
+	var tests = []struct {
+		name string
+		opts []job.Option
+	}{
+		{name: "unlimited", opts: []job.Option{func(j *job.Job) { j.LimitConcurrency = false }}},
+		{name: "replace", opts: []job.Option{job.WithOverlapPolicy(job.OverlapReplace)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := newRunRegistry()
+			j := job.New(uuid.New(), tt.name, cron.EverySecond(), nil, tt.opts...)
+
+			first := newRun(j.Uuid, time.Now())
+			rr.acquire(context.Background(), j, first, reserve)
+			ctx, cancel := first.start(context.Background(), time.Now())
+			defer cancel(nil)
+
+			if err := rr.acquire(context.Background(), j, newRun(j.Uuid, time.Now()), func() error { return errReserve }); !errors.Is(err, errReserve) {
+				t.Errorf("acquire should return the reservation error, received %v", err)
+			}
+			if rr.active(j.Uuid) != 1 {
+				t.Errorf("job should have 1 active run, received %d", rr.active(j.Uuid))
+			}
+			if ctx.Err() != nil {
+				t.Errorf("active run should not be replaced by a run which cannot be reserved")
+			}
+		})
+	}
+}
+
+func TestRunRegistry_QueueReserve(t *testing.T) {
+	errReserve := errors.New("reserve")
+	rr := newRunRegistry()
+	j := job.New(uuid.New(), "queue", cron.EverySecond(), nil, job.WithOverlapPolicy(job.OverlapQueue))
+
+	first := newRun(j.Uuid, time.Now())
+	rr.acquire(context.Background(), j, first, reserve)
+
+	chErr := make(chan error, 1)
+	go func() {
+		chErr <- rr.acquire(context.Background(), j, newRun(j.Uuid, time.Now()), func() error { return errReserve })
+	}()
+
+	for {
+		rr.mux.Lock()
+		queued := len(rr.waiting[j.Uuid])
+		rr.mux.Unlock()
+		if queued > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	rr.release(j.Uuid, first.uuid)
+	select {
+	case err := <-chErr:
+		if !errors.Is(err, errReserve) {
+			t.Errorf("waiting run should return the reservation error, received %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("waiting run should return after the active run is released")
+	}
+	if rr.active(j.Uuid) != 0 {
+		t.Errorf("job should have no active runs, received %d", rr.active(j.Uuid))
+	}
+}
+
+func TestRunRegistry_Cancel(t *testing.T) {
+	rr := newRunRegistry()
+	j := job.New(uuid.New(), "cancel", cron.EverySecond(), nil, job.WithOverlapPolicy(job.OverlapQueue))
+
+	// an active run is cancelled while it runs
+	active := newRun(j.Uuid, time.Now())
+	rr.acquire(context.Background(), j, active, reserve)
+	ctx, cancel := active.start(context.Background(), time.Now())
+	defer cancel(nil)
+	if err := rr.cancel(active.uuid, errRunCancelled); err != nil {
+		t.Fatalf("cannot cancel active run: %v", err)
+	}
+	if !errors.Is(context.Cause(ctx), errRunCancelled) {
+		t.Errorf("cancelled run should be cancelled with errRunCancelled, received %v", context.Cause(ctx))
+	}
+
+	// a waiting run does not become active
+	waiting := newRun(j.Uuid, time.Now())
+	chErr := make(chan error, 1)
+	go func() {
+		chErr <- rr.acquire(context.Background(), j, waiting, reserve)
+	}()
+	for {
+		rr.mux.Lock()
+		queued := len(rr.waiting[j.Uuid])
+		rr.mux.Unlock()
+		if queued > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := rr.cancel(waiting.uuid, errRunCancelled); err != nil {
+		t.Fatalf("cannot cancel waiting run: %v", err)
+	}
+	select {
+	case err := <-chErr:
+		if !errors.Is(err, errRunCancelled) {
+			t.Errorf("cancelled waiting run should return errRunCancelled, received %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("cancelled waiting run should return")
+	}
+
+	// a queued run does not become active when it leaves the queue
+	rr.release(j.Uuid, active.uuid)
+	queued := newRun(j.Uuid, time.Now())
+	rr.queue(queued.uuid)
+	if err := rr.cancel(queued.uuid, errRunCancelled); err != nil {
+		t.Fatalf("cannot cancel queued run: %v", err)
+	}
+	if err := rr.acquire(context.Background(), j, queued, reserve); !errors.Is(err, errRunCancelled) {
+		t.Errorf("cancelled queued run should return errRunCancelled, received %v", err)
+	}
+
+	for _, runUuid := range []uuid.UUID{active.uuid, waiting.uuid, queued.uuid, uuid.New()} {
+		if err := rr.cancel(runUuid, errRunCancelled); !errors.Is(err, ErrRunNotFound) {
+			t.Errorf("finished or unknown run should return ErrRunNotFound, received %v", err)
+		}
+	}
+}
+
+func TestRunRegistry_Running(t *testing.T) {
+	rr := newRunRegistry()
+	j := job.New(uuid.New(), "running", cron.EverySecond(), nil, job.WithConcurrencyLimit(3))
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	runs := []*run{newRun(j.Uuid, now), newRun(j.Uuid, now), newRun(j.Uuid, now)}
+	for _, r := range runs {
+		rr.acquire(context.Background(), j, r, reserve)
+	}
+
+	// the last run is dispatched, but did not start yet
+	for i, r := range runs[:2] {
+		_, cancel := r.start(context.Background(), now.Add(time.Duration(2-i)*time.Second))
+		defer cancel(nil)
+	}
+	runs[0].setProgress(task.Progress{TaskName: "progress", Percent: 10})
+
+	wanted := []ActiveRun{
+		{JobUuid: j.Uuid, RunUuid: runs[1].uuid, TriggerTime: now, StartTime: now.Add(time.Second), Elapsed: 4 * time.Second},
+		{JobUuid: j.Uuid, RunUuid: runs[0].uuid, TriggerTime: now, StartTime: now.Add(2 * time.Second), Elapsed: 3 * time.Second, Progress: task.Progress{TaskName: "progress", Percent: 10}},
+	}
+	if result := rr.running(now.Add(5 * time.Second)); !slices.Equal(result, wanted) {
+		t.Errorf("invalid running runs: got %+v expected %+v", result, wanted)
+	}
+
+	rr.release(j.Uuid, runs[1].uuid)
+	if result := rr.running(now); len(result) != 1 || result[0].RunUuid != runs[0].uuid {
+		t.Errorf("released run should not be running, received %+v", result)
+	}
+}
@@ -0,0 +1,55 @@
+package orchestrator
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/jantytgat/go-jobs/pkg/job"
+)
+
+func newRunWatchers() *runWatchers {
+	return &runWatchers{
+		watchers: make(map[uuid.UUID]chan<- job.Result),
+	}
+}
+
+// runWatchers sends the result of a run to the channel watching the run, for example a backfill waiting for its runs.
+type runWatchers struct {
+	watchers map[uuid.UUID]chan<- job.Result // channels by run
+	mux      sync.Mutex
+}
+
+// watch sends the result of the run with uuid runUuid to ch.
+// The channel must have room for the result, the result handler does not wait for it.
+func (w *runWatchers) watch(runUuid uuid.UUID, ch chan<- job.Result) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	w.watchers[runUuid] = ch
+}
+
+// unwatch stops watching the run with uuid runUuid.
+func (w *runWatchers) unwatch(runUuid uuid.UUID) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	delete(w.watchers, runUuid)
+}
+
+// notify sends result r to the channel watching its run, and stops watching the run.
+func (w *runWatchers) notify(r job.Result) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	ch, ok := w.watchers[r.RunUuid]
+	if !ok {
+		return
+	}
+	delete(w.watchers, r.RunUuid)
+
+	select {
+	case ch <- r:
+	default:
+	}
+}
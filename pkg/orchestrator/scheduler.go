@@ -12,12 +12,15 @@ import (
 	"github.com/jantytgat/go-jobs/pkg/cron"
 )
 
-func newScheduler(logger *slog.Logger, chIn chan schedulerMessage, chOut chan SchedulerTick) *scheduler {
+func newScheduler(logger *slog.Logger, clock cron.Clock, chIn chan schedulerMessage, chOut chan SchedulerTick) *scheduler {
 	s := &scheduler{
-		chIn:    chIn,
-		chOut:   chOut,
-		tickers: make(map[uuid.UUID]*schedulerTicker),
-		logger:  logger.WithGroup("scheduler"),
+		chIn:     chIn,
+		chOut:    chOut,
+		tickers:  make(map[uuid.UUID]*schedulerTicker),
+		finished: make(map[uuid.UUID]string),
+		started:  make(map[uuid.UUID]bool),
+		logger:   logger.WithGroup("scheduler"),
+		clock:    clock,
 	}
 	return s
 }
@@ -28,7 +31,10 @@ type scheduler struct {
 	listenCtx        context.Context
 	listenCancelFunc context.CancelFunc
 	tickers          map[uuid.UUID]*schedulerTicker
+	finished         map[uuid.UUID]string // schedules of jobs which will not be due anymore, so no ticker is started for them
+	started          map[uuid.UUID]bool   // jobs which had a ticker since the scheduler started, so their missed times were handled already
 	logger           *slog.Logger
+	clock            cron.Clock
 	mux              sync.Mutex
 }
 
@@ -42,38 +48,41 @@ func (s *scheduler) IsRunning() bool {
 	return false
 }
 
+// Start starts listening for updates of the schedules of jobs, until ctx is done or the scheduler is stopped.
 func (s *scheduler) Start(ctx context.Context) error {
-	go s.listen(ctx)
-	startCtx, startCancel := context.WithTimeout(ctx, 1*time.Second)
-	defer startCancel()
-	for {
-		select {
-		case <-startCtx.Done():
-			s.logger.LogAttrs(ctx, slog.LevelError, "scheduler start timeout")
-			return fmt.Errorf("scheduler start timeout")
-		default:
-			if s.IsRunning() { // TODO add timeout to start before returning an error?
-				s.logger.LogAttrs(ctx, slog.LevelDebug, "scheduler has started")
-				return nil
-			}
-		}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.listenCancelFunc != nil {
+		return fmt.Errorf("scheduler already started")
 	}
+	s.listenCtx, s.listenCancelFunc = context.WithCancel(ctx)
+	s.started = make(map[uuid.UUID]bool)
+	go s.listen(s.listenCtx)
+
+	s.logger.LogAttrs(ctx, slog.LevelDebug, "scheduler has started")
+	return nil
 }
 
 func (s *scheduler) Stop(ctx context.Context) {
 	s.mux.Lock()
+	defer s.mux.Unlock()
+
 	if s.listenCancelFunc == nil {
-		s.logger.LogAttrs(context.Background(), slog.LevelWarn, "scheduler has stopped already")
+		s.logger.LogAttrs(ctx, slog.LevelWarn, "scheduler has stopped already")
 		return
 	}
-	s.mux.Unlock()
 
-	s.logger.LogAttrs(ctx, slog.LevelDebug, "dispatcher stopping")
+	s.logger.LogAttrs(ctx, slog.LevelDebug, "scheduler stopping")
 	s.listenCancelFunc()
+	s.listenCancelFunc = nil
+}
 
+// forget removes job uuid from the finished jobs, so its schedule is evaluated again when the job is enabled.
+func (s *scheduler) forget(uuid uuid.UUID) {
 	s.mux.Lock()
-	s.listenCancelFunc = nil
-	s.mux.Unlock()
+	defer s.mux.Unlock()
+	delete(s.finished, uuid)
 }
 
 func (s *scheduler) getTicker(uuid uuid.UUID) *schedulerTicker {
@@ -88,9 +97,10 @@ func (s *scheduler) handleUpdate(u schedulerMessage) {
 	if !tickerExists {
 		switch u.enabled {
 		case true:
-			s.startTicker(u.uuid, u.schedule)
+			s.startTicker(u)
 			return
 		case false:
+			s.forget(u.uuid)
 			return
 		}
 	}
@@ -101,14 +111,34 @@ func (s *scheduler) handleUpdate(u schedulerMessage) {
 		return
 	}
 
-	// The ticker exists but the schedule has changed
+	// The ticker exists but the schedule or misfire policy has changed
 	ticker := s.getTicker(u.uuid)
-	if ticker != nil && ticker.schedule.String() != u.schedule.String() {
-		s.updateTicker(u.uuid, u.schedule)
+	if ticker != nil && (ticker.schedule.String() != u.schedule.String() || ticker.misfire != u.misfire) {
+		s.updateTicker(u)
 		return
 	}
 }
 
+// isFinished returns true if the schedule of u will not be due anymore, and no missed times must be fired either.
+// The caller must hold the lock.
+func (s *scheduler) isFinished(u schedulerMessage) bool {
+	if schedule, found := s.finished[u.uuid]; found && schedule == u.schedule.String() {
+		return true
+	}
+
+	now := s.clock.Now().Round(0)
+	if _, ok := u.schedule.Next(now); ok {
+		return false
+	}
+	if !u.lastTrigger.IsZero() && len(u.misfire.Missed(u.schedule, u.lastTrigger, now)) > 0 {
+		return false
+	}
+
+	s.logger.LogAttrs(s.listenCtx, slog.LevelDebug, "schedule will not be due anymore", slog.Group("job", slog.String("id", u.uuid.String()), slog.String("schedule", u.schedule.String())))
+	s.finished[u.uuid] = u.schedule.String()
+	return true
+}
+
 func (s *scheduler) isRunning() bool {
 	s.mux.Lock()
 	defer s.mux.Unlock()
@@ -118,20 +148,32 @@ func (s *scheduler) isRunning() bool {
 	return true
 }
 
-// Start the scheduler to listen for updates from schedulerTickers
+// jobs returns the uuids of the jobs which the scheduler has a ticker for, or knows to be finished.
+func (s *scheduler) jobs() []uuid.UUID {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	uuids := make([]uuid.UUID, 0, len(s.tickers)+len(s.finished))
+	for u := range s.tickers {
+		uuids = append(uuids, u)
+	}
+	for u := range s.finished {
+		uuids = append(uuids, u)
+	}
+	return uuids
+}
+
+// listen listens for updates of the schedules of jobs until ctx is done.
 // If the scheduleTicker cannot be found, add a new one to the map.
 // For each ticker, the scheduler channel chOut is passed so the tickers can send a trigger to the orchestrator when
 // an item must be queued.
 func (s *scheduler) listen(ctx context.Context) {
 	s.logger.LogAttrs(ctx, slog.LevelDebug, "scheduler starting")
 	defer s.logger.LogAttrs(ctx, slog.LevelDebug, "scheduler stopped")
-	s.mux.Lock()
-	s.listenCtx, s.listenCancelFunc = context.WithCancel(ctx)
-	s.mux.Unlock()
 
 	for {
 		select {
-		case <-s.listenCtx.Done():
+		case <-ctx.Done():
 			// All tickers will be stopped as well as their context is based on s.listenCtx
 			return
 		case u := <-s.chIn:
@@ -140,13 +182,26 @@ func (s *scheduler) listen(ctx context.Context) {
 	}
 }
 
-func (s *scheduler) startTicker(uuid uuid.UUID, schedule cron.Schedule) {
+func (s *scheduler) startTicker(u schedulerMessage) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 
-	s.logger.LogAttrs(s.listenCtx, slog.LevelDebug, "starting ticker", slog.Group("job", slog.String("id", uuid.String()), slog.String("schedule", schedule.String())))
-	s.tickers[uuid] = newSchedulerTicker(uuid, schedule)
-	s.tickers[uuid].Start(s.listenCtx, s.chOut)
+	// Missed times are only fired when the ticker starts for the first time, not when the job is enabled again
+	if s.started[u.uuid] {
+		u.lastTrigger = time.Time{}
+	}
+	if s.isFinished(u) {
+		return
+	}
+
+	s.logger.LogAttrs(s.listenCtx, slog.LevelDebug, "starting ticker", slog.Group("job", slog.String("id", u.uuid.String()), slog.String("schedule", u.schedule.String()), slog.String("misfire", u.misfire.Mode.String())))
+	ticker := newSchedulerTicker(u.uuid, u.schedule, u.misfire, u.lastTrigger, s.clock)
+	if err := ticker.Start(s.listenCtx, s.chOut); err != nil {
+		s.logger.LogAttrs(s.listenCtx, slog.LevelError, "failed to start ticker", slog.Group("job", slog.String("id", u.uuid.String())), slog.String("error", err.Error()))
+		return
+	}
+	s.tickers[u.uuid] = ticker
+	s.started[u.uuid] = true
 }
 
 func (s *scheduler) stopAndRemoveTicker(uuid uuid.UUID) {
@@ -170,13 +225,24 @@ func (s *scheduler) tickerExists(uuid uuid.UUID) bool {
 	return false
 }
 
-func (s *scheduler) updateTicker(uuid uuid.UUID, schedule cron.Schedule) {
+func (s *scheduler) updateTicker(u schedulerMessage) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 
-	s.tickers[uuid].Stop()
-	s.tickers[uuid].schedule = schedule
-	s.tickers[uuid].Start(s.listenCtx, s.chOut)
-	s.logger.LogAttrs(s.listenCtx, slog.LevelDebug, "updated ticker", slog.Group("job", slog.String("id", uuid.String()), slog.String("schedule", s.tickers[uuid].schedule.String())))
+	s.tickers[u.uuid].Stop()
+	u.lastTrigger = time.Time{} // missed times were handled when the ticker started
+	if s.isFinished(u) {
+		delete(s.tickers, u.uuid)
+		return
+	}
 
+	s.tickers[u.uuid].schedule = u.schedule
+	s.tickers[u.uuid].misfire = u.misfire
+	s.tickers[u.uuid].lastTrigger = u.lastTrigger
+	if err := s.tickers[u.uuid].Start(s.listenCtx, s.chOut); err != nil {
+		s.logger.LogAttrs(s.listenCtx, slog.LevelError, "failed to start ticker", slog.Group("job", slog.String("id", u.uuid.String())), slog.String("error", err.Error()))
+		delete(s.tickers, u.uuid)
+		return
+	}
+	s.logger.LogAttrs(s.listenCtx, slog.LevelDebug, "updated ticker", slog.Group("job", slog.String("id", u.uuid.String()), slog.String("schedule", s.tickers[u.uuid].schedule.String())))
 }
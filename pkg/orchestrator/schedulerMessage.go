@@ -1,13 +1,17 @@
 package orchestrator
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 
 	"github.com/jantytgat/go-jobs/pkg/cron"
 )
 
 type schedulerMessage struct {
-	uuid     uuid.UUID
-	enabled  bool
-	schedule cron.Schedule
+	uuid        uuid.UUID
+	enabled     bool
+	schedule    cron.Timetable
+	misfire     cron.MisfirePolicy
+	lastTrigger time.Time // only set when the ticker for the job is not running
 }
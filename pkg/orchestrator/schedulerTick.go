@@ -4,9 +4,15 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/jantytgat/go-jobs/pkg/job"
+	"github.com/jantytgat/go-jobs/pkg/task"
 )
 
 type SchedulerTick struct {
-	uuid uuid.UUID
-	time time.Time
+	uuid    uuid.UUID
+	time    time.Time
+	run     uuid.UUID   // uuid of the run, only set for manual triggers so it is known before the run is dispatched
+	trigger job.Trigger // cause of the tick
+	tasks   []task.Task // tasks of the run instead of the tasks of the job, only set when a manual trigger overrides tasks
 }
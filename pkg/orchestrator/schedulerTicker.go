@@ -10,33 +10,49 @@ import (
 	"github.com/jantytgat/go-jobs/pkg/cron"
 )
 
-func newSchedulerTicker(uuid uuid.UUID, schedule cron.Schedule) *schedulerTicker {
+func newSchedulerTicker(uuid uuid.UUID, schedule cron.Timetable, misfire cron.MisfirePolicy, lastTrigger time.Time, clock cron.Clock) *schedulerTicker {
 	return &schedulerTicker{
-		Uuid:     uuid,
-		schedule: schedule,
-		chTime:   make(chan time.Time),
+		Uuid:        uuid,
+		schedule:    schedule,
+		misfire:     misfire,
+		lastTrigger: lastTrigger,
+		clock:       clock,
+		chTime:      make(chan time.Time),
 	}
 }
 
 type schedulerTicker struct {
 	Uuid         uuid.UUID
-	schedule     cron.Schedule
+	schedule     cron.Timetable
+	misfire      cron.MisfirePolicy
+	lastTrigger  time.Time
+	clock        cron.Clock
 	chTime       chan time.Time
 	ticker       *cron.Ticker
 	tickerCancel context.CancelFunc
 	mux          sync.Mutex
 }
 
-// TODO Start return error?
-func (s *schedulerTicker) Start(ctx context.Context, chTick chan SchedulerTick) {
-	go s.tick(ctx, chTick)
+// Start starts the cron ticker for the schedule, and forwards its times to chTick until ctx is done, the ticker is stopped,
+// or the schedule will not be due anymore.
+func (s *schedulerTicker) Start(ctx context.Context, chTick chan SchedulerTick) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
 
-	// Wait until the ticker has started
-	for {
-		if s.isRunning() {
-			return
-		}
+	var tickerCtx context.Context
+	tickerCtx, s.tickerCancel = context.WithCancel(ctx)
+	s.ticker = cron.NewTicker(s.schedule, s.chTime,
+		cron.WithTickerMode(cron.TickerModeTimer),
+		cron.WithMisfirePolicy(s.misfire),
+		cron.WithLastTrigger(s.lastTrigger),
+		cron.WithClock(s.clock))
+	if err := s.ticker.Start(tickerCtx); err != nil {
+		s.tickerCancel()
+		return err
 	}
+
+	go s.tick(tickerCtx, s.ticker.Done(), chTick)
+	return nil
 }
 
 func (s *schedulerTicker) Stop() {
@@ -47,38 +63,23 @@ func (s *schedulerTicker) Stop() {
 	}
 }
 
-func (s *schedulerTicker) isRunning() bool {
-	s.mux.Lock()
-	defer s.mux.Unlock()
-
-	if s.ticker != nil {
-		return s.ticker.IsRunning()
-	}
-	return false
-}
-
-// Listen on the scheduler channel chTime for triggers from the tickers.
+// tick listens on chTime for triggers from the cron ticker, until ctx is done or the cron ticker is done.
 // When a time tick is received, create a scheduler SchedulerTick and forward it
-func (s *schedulerTicker) tick(ctx context.Context, chTick chan SchedulerTick) {
-	s.mux.Lock()
-	var tickerCtx context.Context
-	tickerCtx, s.tickerCancel = context.WithCancel(ctx)
-	s.ticker = cron.NewTicker(s.schedule, s.chTime)
-	err := s.ticker.Start(tickerCtx)
-	s.mux.Unlock()
-
-	if err != nil {
-		return
-	}
-
+func (s *schedulerTicker) tick(ctx context.Context, done <-chan struct{}, chTick chan SchedulerTick) {
 	for {
 		select {
-		case <-tickerCtx.Done():
+		case <-ctx.Done():
+			return
+		case <-done:
 			return
 		case t := <-s.chTime:
-			chTick <- SchedulerTick{
+			select {
+			case <-ctx.Done():
+				return
+			case chTick <- SchedulerTick{
 				uuid: s.Uuid,
 				time: t,
+			}:
 			}
 		}
 	}
@@ -5,4 +5,5 @@ import "github.com/jantytgat/go-jobs/pkg/task"
 type Statistics struct {
 	HandlerPoolStatistics map[string]task.HandlerPoolStatistics
 	QueueLength           int
+	ActiveRuns            []ActiveRun
 }
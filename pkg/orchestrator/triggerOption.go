@@ -0,0 +1,66 @@
+package orchestrator
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/jantytgat/go-jobs/pkg/task"
+)
+
+// TriggerOption changes a manual run of a job, see Orchestrator.Trigger.
+type TriggerOption func(*trigger)
+
+// trigger holds the tasks of a manual run, starting from a copy of the tasks of the job.
+type trigger struct {
+	tasks      []task.Task
+	overridden bool  // the tasks differ from the tasks of the job
+	err        error // first error of the options
+}
+
+func newTrigger(tasks []task.Task) *trigger {
+	return &trigger{tasks: slices.Clone(tasks)}
+}
+
+// WithTaskOverride runs task t instead of the task at index i of the job.
+// Task t must have the same type as the task it replaces.
+func WithTaskOverride(i int, t task.Task) TriggerOption {
+	return func(tr *trigger) {
+		if tr.err != nil {
+			return
+		}
+		if i < 0 || i >= len(tr.tasks) {
+			tr.err = fmt.Errorf("task index %d out of range, job has %d tasks", i, len(tr.tasks))
+			return
+		}
+		if reflect.TypeOf(t) != reflect.TypeOf(tr.tasks[i]) {
+			tr.err = fmt.Errorf("task %d has type %T, received %T", i, tr.tasks[i], t)
+			return
+		}
+
+		tr.tasks[i] = t
+		tr.overridden = true
+	}
+}
+
+// WithTaskParams decodes spec over the fields of the task at index i of the job, see task.Override.
+// Fields which are not in spec keep the value of the job.
+func WithTaskParams(i int, spec task.Spec) TriggerOption {
+	return func(tr *trigger) {
+		if tr.err != nil {
+			return
+		}
+		if i < 0 || i >= len(tr.tasks) {
+			tr.err = fmt.Errorf("task index %d out of range, job has %d tasks", i, len(tr.tasks))
+			return
+		}
+
+		t, err := task.Override(tr.tasks[i], spec)
+		if err != nil {
+			tr.err = fmt.Errorf("task %d: %w", i, err)
+			return
+		}
+		tr.tasks[i] = t
+		tr.overridden = true
+	}
+}
@@ -0,0 +1,79 @@
+package task
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NewDefinition returns the Definition of task t, whose type must be registered in the DefaultRegistry.
+func NewDefinition(t Task) (Definition, error) {
+	kind, err := KindOf(t)
+	if err != nil {
+		return Definition{}, err
+	}
+	return Definition{Kind: kind, Task: t}, nil
+}
+
+// Definition is the serializable form of a task.
+// It is encoded as the kind the task type is registered under in the DefaultRegistry, and the task itself as spec:
+//
+//	{"kind": "log", "spec": {"Level": "INFO", "Message": "hello"}}
+type Definition struct {
+	Kind string
+	Task Task
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Definition) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind string `json:"kind"`
+		Spec Task   `json:"spec"`
+	}{d.Kind, d.Task})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Definition) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Kind string          `json:"kind"`
+		Spec json.RawMessage `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	t, err := DefaultRegistry.Decode(raw.Kind, JSONSpec(raw.Spec))
+	if err != nil {
+		return err
+	}
+
+	d.Kind, d.Task = raw.Kind, t
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (d Definition) MarshalYAML() (any, error) {
+	return struct {
+		Kind string `yaml:"kind"`
+		Spec Task   `yaml:"spec"`
+	}{d.Kind, d.Task}, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Definition) UnmarshalYAML(node *yaml.Node) error {
+	var raw struct {
+		Kind string    `yaml:"kind"`
+		Spec yaml.Node `yaml:"spec"`
+	}
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+
+	t, err := DefaultRegistry.Decode(raw.Kind, YAMLSpec(&raw.Spec))
+	if err != nil {
+		return err
+	}
+
+	d.Kind, d.Task = raw.Kind, t
+	return nil
+}
@@ -0,0 +1,91 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type definitionTestTask struct {
+	Message string
+	Repeat  int
+}
+
+func (t definitionTestTask) Name() string                                      { return "definitionTestTask" }
+func (t definitionTestTask) DefaultHandler() Handler                           { return Handler{} }
+func (t definitionTestTask) Handler(_ time.Duration) Handler                   { return Handler{} }
+func (t definitionTestTask) DefaultHandlerPool(_ context.Context) *HandlerPool { return nil }
+func (t definitionTestTask) HandlerPool(_ context.Context, _ time.Duration) *HandlerPool {
+	return nil
+}
+
+func init() {
+	_ = RegisterType("definition-test", definitionTestTask{})
+}
+
+func TestDefinition_JSON(t *testing.T) {
+	d, err := NewDefinition(definitionTestTask{Message: "hello", Repeat: 2})
+	if err != nil {
+		t.Fatalf("cannot create definition: %v", err)
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("cannot marshal definition: %v", err)
+	}
+	if wanted := `{"kind":"definition-test","spec":{"Message":"hello","Repeat":2}}`; string(data) != wanted {
+		t.Errorf("definition should marshal to %s, received %s", wanted, data)
+	}
+
+	var decoded Definition
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("cannot unmarshal %s: %v", data, err)
+	}
+	if !reflect.DeepEqual(decoded, d) {
+		t.Errorf("decoded definition should be %v, received %v", d, decoded)
+	}
+}
+
+func TestDefinition_YAML(t *testing.T) {
+	d, err := NewDefinition(definitionTestTask{Message: "hello", Repeat: 2})
+	if err != nil {
+		t.Fatalf("cannot create definition: %v", err)
+	}
+
+	data, err := yaml.Marshal(d)
+	if err != nil {
+		t.Fatalf("cannot marshal definition: %v", err)
+	}
+
+	var decoded Definition
+	if err = yaml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("cannot unmarshal %s: %v", data, err)
+	}
+	if !reflect.DeepEqual(decoded, d) {
+		t.Errorf("decoded definition should be %v, received %v", d, decoded)
+	}
+}
+
+func TestDefinition_UnmarshalInvalid(t *testing.T) {
+	var tests = []struct {
+		name string
+		data string
+	}{
+		{name: "unknown kind", data: `{"kind":"unknown","spec":{}}`},
+		{name: "missing kind", data: `{"spec":{}}`},
+		{name: "invalid spec", data: `{"kind":"definition-test","spec":{"Repeat":"twice"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Definition
+			if err := json.Unmarshal([]byte(tt.data), &d); err == nil {
+				t.Errorf("%s should return an error", tt.data)
+			}
+		})
+	}
+}
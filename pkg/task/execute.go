@@ -5,9 +5,10 @@ import (
 	"log/slog"
 )
 
-func Execute(ctx context.Context, l *slog.Logger, task Task, r *HandlerRepository) (Result, error) {
-	pipeline := NewPipeline(l)
-	chResults := make(chan HandlerResult)
+func Execute(ctx context.Context, l *slog.Logger, task Task, r *HandlerRepository, opts ...PipelineOption) (Result, error) {
+	pipeline := NewPipeline(l, opts...)
+	chResults := make(chan HandlerResult, 1) // the handler pool can send the result after ctx is done
+	pipeline.startTask(0, task)
 	if err := r.Execute(ctx, NewHandlerTaskWithChannel(task, pipeline, chResults)); err != nil {
 		return Result{}, err
 	}
@@ -26,9 +27,9 @@ func Execute(ctx context.Context, l *slog.Logger, task Task, r *HandlerRepositor
 	}
 }
 
-func ExecuteSequence(ctx context.Context, l *slog.Logger, tasks []Task, r *HandlerRepository) ([]Result, error) {
-	pipeline := NewPipeline(l)
-	chResults := make(chan HandlerResult)
+func ExecuteSequence(ctx context.Context, l *slog.Logger, tasks []Task, r *HandlerRepository, opts ...PipelineOption) ([]Result, error) {
+	pipeline := NewPipeline(l, opts...)
+	chResults := make(chan HandlerResult, 1) // the handler pool can send the result after ctx is done
 	results := make([]Result, len(tasks))
 	for i, task := range tasks {
 		// If the HandlerPool cannot be found in the HandlerRepository, the repository will first try to register
@@ -36,6 +37,7 @@ func ExecuteSequence(ctx context.Context, l *slog.Logger, tasks []Task, r *Handl
 		// We cannot proceed with the execution of the sequence, so we return the error to be handled by the caller.
 		// The handler pool will send the result of the task to s.chResults.
 		// Any data that needs to be passed on through the sequence of tasks is stored in the pipeline by the task handler.
+		pipeline.startTask(i, task)
 		if err := r.Execute(ctx, NewHandlerTaskWithChannel(task, pipeline, chResults)); err != nil {
 			return results, err
 		}
@@ -48,6 +50,10 @@ func ExecuteSequence(ctx context.Context, l *slog.Logger, tasks []Task, r *Handl
 			}
 			select {
 			case <-ctx.Done():
+				results[i] = Result{
+					Status: StatusCanceled,
+					Error:  ctx.Err(),
+				}
 				return results, ctx.Err()
 			case result := <-chResults:
 				results[i] = Result{
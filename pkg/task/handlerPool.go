@@ -110,18 +110,23 @@ func (p *HandlerPool) increaseTasksWaiting() {
 	handlerPoolMetrics.tasksWaiting.WithLabelValues(p.handler.Name).Inc()
 }
 
+// launchWorkers starts maxWorkers workers, and starts a new worker each time a worker is recycled, until ctx is done.
 func (p *HandlerPool) launchWorkers(ctx context.Context) {
 	handlerPoolMetrics.maxWorkers.WithLabelValues(p.handler.Name).Set(float64(p.maxWorkers))
+
+	// There are never more than maxWorkers workers, so recycled workers do not block once ctx is done.
+	chRecycled := make(chan struct{}, p.maxWorkers)
+	for i := 0; i < p.maxWorkers; i++ {
+		p.startWorker(ctx, chRecycled)
+	}
+
 	for {
-		p.mux.Lock()
-		if p.workers < p.maxWorkers {
-			for i := 0; i < p.maxWorkers-p.workers; i++ {
-				p.workers++
-				handlerPoolMetrics.workers.WithLabelValues(p.handler.Name).Inc()
-				go p.runWorker(ctx)
-			}
+		select {
+		case <-ctx.Done():
+			return
+		case <-chRecycled:
+			p.startWorker(ctx, chRecycled)
 		}
-		p.mux.Unlock()
 	}
 }
 
@@ -184,7 +189,8 @@ Cleanup:
 	}
 }
 
-func (p *HandlerPool) runWorker(ctx context.Context) {
+// runWorker executes tasks from the worker input channel, and returns true if the worker was recycled.
+func (p *HandlerPool) runWorker(ctx context.Context) bool {
 	var recycle bool
 	tasksExecuted := 0
 
@@ -205,7 +211,7 @@ Run:
 
 			p.increaseActiveWorkerCount()
 
-			status, err := p.handler.Execute(ctx, t.Task, t.Pipeline)
+			status, err := p.execute(ctx, t)
 			if t.ChResult != nil {
 				t.ChResult <- HandlerResult{
 					Task:   t.Task,
@@ -221,6 +227,31 @@ Run:
 	}
 
 	p.decreaseWorkerCount(recycle)
+	return recycle
+}
+
+// execute runs the handler for task t, until the handler returns or either the worker context or the context of the task is done.
+func (p *HandlerPool) execute(ctx context.Context, t HandlerTask) (Status, error) {
+	taskCtx, taskCancel := context.WithCancelCause(t.Context())
+	defer taskCancel(nil)
+
+	stop := context.AfterFunc(ctx, func() { taskCancel(context.Cause(ctx)) })
+	defer stop()
+
+	return p.handler.Execute(taskCtx, t.Task, t.Pipeline)
+}
+
+func (p *HandlerPool) startWorker(ctx context.Context, chRecycled chan<- struct{}) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.workers++
+	handlerPoolMetrics.workers.WithLabelValues(p.handler.Name).Inc()
+	go func() {
+		if p.runWorker(ctx) {
+			chRecycled <- struct{}{}
+		}
+	}()
 }
 
 func (p *HandlerPool) sendToWorker(ht HandlerTask) {
@@ -1,3 +1,37 @@
 package task
 
-// TODO HandlerPool tests
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHandlerPool_TaskContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chStarted := make(chan struct{})
+	h := NewHandler("handlerPoolTest", 5*time.Second, func(ctx context.Context, _ Task, _ *Pipeline) error {
+		close(chStarted)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	p := NewHandlerPool(ctx, h, 1)
+
+	taskCtx, taskCancel := context.WithCancel(context.Background())
+	chResult := make(chan HandlerResult, 1)
+	p.ChPoolInput <- NewHandlerTaskWithChannel(definitionTestTask{}, nil, chResult).WithContext(taskCtx)
+
+	<-chStarted
+	taskCancel()
+
+	select {
+	case r := <-chResult:
+		if r.Status != StatusCanceled || !errors.Is(r.Error, context.Canceled) {
+			t.Errorf("cancelled task should have status %s, received %s (%v)", StatusCanceled, r.Status, r.Error)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("cancelling the context of the task should cancel its handler")
+	}
+}
@@ -12,7 +12,9 @@ import (
 func NewHandlerRepository(name string, opts ...HandlerRepositoryOption) *HandlerRepository {
 	r := &HandlerRepository{
 		name:         name,
+		ctx:          context.Background(),
 		handlerPools: make(map[string]*HandlerPool),
+		defaultPools: make(map[string]context.Context),
 		mux:          sync.RWMutex{},
 	}
 
@@ -28,7 +30,9 @@ func NewHandlerRepository(name string, opts ...HandlerRepositoryOption) *Handler
 
 type HandlerRepository struct {
 	name         string
+	ctx          context.Context // lifetime of the default handler pools registered by Execute
 	handlerPools map[string]*HandlerPool
+	defaultPools map[string]context.Context // names of the default handler pools, with the context they run with
 	reg          prometheus.Registerer
 	mux          sync.RWMutex
 }
@@ -38,7 +42,8 @@ func (r *HandlerRepository) Execute(ctx context.Context, t HandlerTask) error {
 
 	if err != nil {
 		// Try to register the default handler pool for task, return on error
-		if err = r.registerHandlerPool(t.Task.DefaultHandlerPool(ctx)); err != nil {
+		// The pool serves later executions as well, so it runs with the lifetime context of the repository instead of ctx.
+		if err = r.registerDefaultHandlerPool(t.Task); err != nil {
 			return err
 		}
 
@@ -48,9 +53,14 @@ func (r *HandlerRepository) Execute(ctx context.Context, t HandlerTask) error {
 		}
 	}
 
-	// Send the task to the handler pool channel
-	handlerPool.ChPoolInput <- t
-	return nil
+	// Send the task to the handler pool channel, unless the context is done while the pool is busy
+	// The task keeps ctx, so cancelling ctx also cancels the task while its handler executes it.
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case handlerPool.ChPoolInput <- t.WithContext(ctx):
+		return nil
+	}
 }
 
 func (r *HandlerRepository) RegisterHandlerPools(p []*HandlerPool) error {
@@ -62,6 +72,17 @@ func (r *HandlerRepository) RegisterHandlerPools(p []*HandlerPool) error {
 	return nil
 }
 
+// Start sets the lifetime of the default handler pools which Execute registers for tasks without a handler pool.
+// These pools stop when ctx is done, and are removed from the repository so a later Execute registers them again.
+// Without Start, they run until the process exits.
+func (r *HandlerRepository) Start(ctx context.Context) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.ctx = ctx
+	context.AfterFunc(ctx, func() { r.removeDefaultHandlerPools(ctx) })
+}
+
 func (r *HandlerRepository) Statistics() map[string]HandlerPoolStatistics {
 	r.mux.RLock()
 	defer r.mux.RUnlock()
@@ -84,6 +105,32 @@ func (r *HandlerRepository) get(name string) (*HandlerPool, error) {
 	return pool, nil
 }
 
+// registerDefaultHandlerPool registers the default handler pool of t, which runs until the lifetime context of the repository is done.
+func (r *HandlerRepository) registerDefaultHandlerPool(t Task) error {
+	r.mux.RLock()
+	ctx := r.ctx
+	r.mux.RUnlock()
+
+	p := t.DefaultHandlerPool(ctx)
+	if err := r.registerHandlerPool(p); err != nil {
+		return err
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.handlerPools[p.Name()] != p {
+		return nil // another pool was registered in the meantime
+	}
+	r.defaultPools[p.Name()] = ctx
+
+	// The pools of ctx might have been removed before p was registered
+	if err := ctx.Err(); err != nil {
+		r.removeHandlerPool(p.Name())
+		return err
+	}
+	return nil
+}
+
 func (r *HandlerRepository) registerHandlerPool(p *HandlerPool) error {
 	r.mux.Lock()
 	defer r.mux.Unlock()
@@ -103,3 +150,24 @@ func (r *HandlerRepository) registerHandlerPool(p *HandlerPool) error {
 
 	return nil
 }
+
+// removeDefaultHandlerPools removes the default handler pools which run with ctx.
+func (r *HandlerRepository) removeDefaultHandlerPools(ctx context.Context) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	for name, poolCtx := range r.defaultPools {
+		if poolCtx == ctx {
+			r.removeHandlerPool(name)
+		}
+	}
+}
+
+// removeHandlerPool removes the handler pool with name, the caller must hold the lock.
+func (r *HandlerRepository) removeHandlerPool(name string) {
+	if _, found := r.handlerPools[name]; found {
+		delete(r.handlerPools, name)
+		delete(r.defaultPools, name)
+		handlerRepositoryMetrics.handlerPools.WithLabelValues(r.name).Dec()
+	}
+}
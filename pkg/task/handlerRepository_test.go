@@ -1,3 +1,60 @@
 package task
 
-// TODO HandlerRepository tests
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type handlerRepositoryTestTask struct {
+	chPool chan *HandlerPool // receives each default handler pool which is created
+}
+
+func (t handlerRepositoryTestTask) Name() string            { return "handlerRepositoryTestTask" }
+func (t handlerRepositoryTestTask) DefaultHandler() Handler { return t.Handler(time.Second) }
+func (t handlerRepositoryTestTask) DefaultHandlerPool(ctx context.Context) *HandlerPool {
+	return t.HandlerPool(ctx, time.Second)
+}
+func (t handlerRepositoryTestTask) Handler(timeout time.Duration) Handler {
+	return NewHandler(t.Name(), timeout, func(_ context.Context, _ Task, _ *Pipeline) error { return nil })
+}
+func (t handlerRepositoryTestTask) HandlerPool(ctx context.Context, timeout time.Duration) *HandlerPool {
+	p := NewHandlerPool(ctx, t.Handler(timeout), 1)
+	t.chPool <- p
+	return p
+}
+
+func TestHandlerRepository_Start(t *testing.T) {
+	r := NewHandlerRepository("handlerRepositoryTest")
+	tsk := handlerRepositoryTestTask{chPool: make(chan *HandlerPool, 2)}
+
+	// the default handler pool is registered again after the context of the previous start is done
+	for i := range 2 {
+		ctx, cancel := context.WithCancel(context.Background())
+		r.Start(ctx)
+
+		chResult := make(chan HandlerResult, 1)
+		if err := r.Execute(context.Background(), NewHandlerTaskWithChannel(tsk, nil, chResult)); err != nil {
+			t.Fatalf("cannot execute task: %v", err)
+		}
+		select {
+		case <-chResult:
+		case <-time.After(time.Second):
+			t.Fatalf("task should be executed by the default handler pool")
+		}
+
+		var p *HandlerPool
+		select {
+		case p = <-tsk.chPool:
+		default:
+			t.Fatalf("start %d should register a new default handler pool", i)
+		}
+
+		cancel()
+		for start := time.Now(); p.IsRunning() || len(r.Statistics()) > 0; time.Sleep(10 * time.Millisecond) {
+			if time.Since(start) > 5*time.Second {
+				t.Fatalf("default handler pool should stop and be removed when the context of the repository is done")
+			}
+		}
+	}
+}
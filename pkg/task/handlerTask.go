@@ -1,5 +1,7 @@
 package task
 
+import "context"
+
 func NewHandlerTask(t Task, p *Pipeline) HandlerTask {
 	return HandlerTask{
 		Task:     t,
@@ -20,4 +22,20 @@ type HandlerTask struct {
 	Task     Task
 	Pipeline *Pipeline
 	ChResult chan HandlerResult
+	ctx      context.Context
+}
+
+// Context returns the context of the task, which cancels the execution of the task by its handler.
+// Returns context.Background if the task has no context.
+func (t HandlerTask) Context() context.Context {
+	if t.ctx == nil {
+		return context.Background()
+	}
+	return t.ctx
+}
+
+// WithContext returns a copy of the task with its context set to ctx.
+func (t HandlerTask) WithContext(ctx context.Context) HandlerTask {
+	t.ctx = ctx
+	return t
 }
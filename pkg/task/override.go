@@ -0,0 +1,42 @@
+package task
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Override returns a copy of t with the fields in spec decoded over the fields of t, for example to run a task with other parameters.
+// Fields which are not in spec keep their value, t itself is not changed.
+// Returns an error if the spec cannot be decoded, or the copy is not valid.
+func Override(t Task, spec Spec) (Task, error) {
+	if t == nil {
+		return nil, fmt.Errorf("task required")
+	}
+
+	// the spec is decoded into a new value holding a copy of t, pointer types are copied from the value they point to
+	typ := reflect.TypeOf(t)
+	var v reflect.Value
+	if typ.Kind() == reflect.Pointer {
+		v = reflect.New(typ.Elem())
+		v.Elem().Set(reflect.ValueOf(t).Elem())
+	} else {
+		v = reflect.New(typ)
+		v.Elem().Set(reflect.ValueOf(t))
+	}
+
+	if err := spec.Decode(v.Interface()); err != nil {
+		return nil, fmt.Errorf("invalid spec for task %s: %w", t.Name(), err)
+	}
+
+	o := v.Interface().(Task)
+	if typ.Kind() != reflect.Pointer {
+		o = v.Elem().Interface().(Task)
+	}
+
+	if val, ok := o.(Validator); ok {
+		if err := val.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid task %s: %w", t.Name(), err)
+		}
+	}
+	return o, nil
+}
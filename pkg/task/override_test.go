@@ -0,0 +1,56 @@
+package task
+
+import (
+	"testing"
+)
+
+func TestOverride(t *testing.T) {
+	base := registryTestTask{definitionTestTask: definitionTestTask{Message: "hello", Repeat: 2}, Target: "world"}
+
+	var tests = []struct {
+		name    string
+		task    Task
+		spec    Spec
+		wanted  Task
+		wantErr bool
+	}{
+		{name: "partial", task: base, spec: JSONSpec([]byte(`{"Message": "bye"}`)), wanted: registryTestTask{definitionTestTask: definitionTestTask{Message: "bye", Repeat: 2}, Target: "world"}},
+		{name: "empty", task: base, spec: JSONSpec(nil), wanted: base},
+		{name: "pointer", task: &definitionTestTask{Message: "hello"}, spec: JSONSpec([]byte(`{"Repeat": 3}`)), wanted: &definitionTestTask{Message: "hello", Repeat: 3}},
+		{name: "unknown field", task: base, spec: JSONSpec([]byte(`{"Unknown": true}`)), wantErr: true},
+		{name: "invalid", task: base, spec: JSONSpec([]byte(`{"Target": ""}`)), wantErr: true},
+		{name: "nil task", task: nil, spec: JSONSpec(nil), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Override(tt.task, tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("override should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cannot override task: %v", err)
+			}
+
+			if p, ok := result.(*definitionTestTask); ok {
+				if *p != *tt.wanted.(*definitionTestTask) {
+					t.Errorf("invalid task: got %+v expected %+v", *p, *tt.wanted.(*definitionTestTask))
+				}
+				if p == tt.task {
+					t.Errorf("override should copy the task")
+				}
+				return
+			}
+			if result != tt.wanted {
+				t.Errorf("invalid task: got %+v expected %+v", result, tt.wanted)
+			}
+		})
+	}
+
+	if base.Message != "hello" {
+		t.Errorf("override should not change the task")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 )
 
 var pipelineDataFields = make([]string, 0)
@@ -27,19 +28,27 @@ func RegisterPipelineDataFields(s []string) error {
 	return nil
 }
 
-func NewPipeline(l *slog.Logger) *Pipeline {
-	return &Pipeline{
+func NewPipeline(l *slog.Logger, opts ...PipelineOption) *Pipeline {
+	p := &Pipeline{
 		logger: l,
 		data:   make(map[string]interface{}),
 		errors: make([]error, 0),
 	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 type Pipeline struct {
-	logger *slog.Logger
-	data   map[string]interface{}
-	errors []error
-	mux    sync.RWMutex
+	logger      *slog.Logger
+	triggerTime time.Time
+	progress    Progress
+	onProgress  func(Progress) // called with the progress when it changes
+	data        map[string]interface{}
+	errors      []error
+	mux         sync.RWMutex
 }
 
 func (p *Pipeline) Data() map[string]interface{} {
@@ -82,6 +91,31 @@ func (p *Pipeline) Keys() []string {
 	return keys
 }
 
+// Progress returns the current task and its progress.
+func (p *Pipeline) Progress() Progress {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+	return p.progress
+}
+
+// ReportMessage reports what the current task is doing, for example the step it is working on.
+func (p *Pipeline) ReportMessage(message string) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.progress.Message = message
+	p.notifyProgress()
+}
+
+// ReportProgress reports the progress of the current task in percent, limited to the range from 0 to 100.
+func (p *Pipeline) ReportProgress(percent float64) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.progress.Percent = min(max(percent, 0), 100)
+	p.notifyProgress()
+}
+
 func (p *Pipeline) Logger(t Task) *slog.Logger {
 	return p.logger.With(LogTaskAttr(t))
 }
@@ -91,3 +125,28 @@ func (p *Pipeline) Set(key string, value interface{}) {
 	defer p.mux.Unlock()
 	p.data[key] = value
 }
+
+// startTask resets the progress for task t at index i of the sequence.
+func (p *Pipeline) startTask(i int, t Task) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.progress = Progress{
+		TaskIndex: i,
+		TaskName:  t.Name(),
+	}
+	p.notifyProgress()
+}
+
+// notifyProgress calls onProgress with the current progress, the caller must hold the lock.
+func (p *Pipeline) notifyProgress() {
+	if p.onProgress != nil {
+		p.onProgress(p.progress)
+	}
+}
+
+// TriggerTime returns the logical time the tasks run for, such as the fire time of a schedule.
+// For runs which replay a past time, it differs from the wall-clock time. Returns the zero time if it is not set.
+func (p *Pipeline) TriggerTime() time.Time {
+	return p.triggerTime
+}
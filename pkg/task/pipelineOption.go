@@ -0,0 +1,20 @@
+package task
+
+import "time"
+
+type PipelineOption func(*Pipeline)
+
+// WithPipelineTriggerTime sets the logical time the tasks in the pipeline run for, see Pipeline.TriggerTime.
+func WithPipelineTriggerTime(t time.Time) PipelineOption {
+	return func(p *Pipeline) {
+		p.triggerTime = t
+	}
+}
+
+// WithPipelineProgress calls f with the progress of the pipeline each time it changes, see Pipeline.Progress.
+// Function f must return quickly, as it blocks the task reporting the progress.
+func WithPipelineProgress(f func(Progress)) PipelineOption {
+	return func(p *Pipeline) {
+		p.onProgress = f
+	}
+}
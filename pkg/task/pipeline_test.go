@@ -1,3 +1,50 @@
 package task
 
-// TODO Pipeline tests
+import (
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPipeline_TriggerTime(t *testing.T) {
+	triggerTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if p := NewPipeline(slog.New(slog.DiscardHandler)); !p.TriggerTime().IsZero() {
+		t.Errorf("trigger time should be zero by default, received %s", p.TriggerTime())
+	}
+	if p := NewPipeline(slog.New(slog.DiscardHandler), WithPipelineTriggerTime(triggerTime)); !p.TriggerTime().Equal(triggerTime) {
+		t.Errorf("invalid trigger time: got %s expected %s", p.TriggerTime(), triggerTime)
+	}
+}
+
+func TestPipeline_Progress(t *testing.T) {
+	var reported []Progress
+	p := NewPipeline(slog.New(slog.DiscardHandler), WithPipelineProgress(func(progress Progress) {
+		reported = append(reported, progress)
+	}))
+
+	p.startTask(1, definitionTestTask{})
+	p.ReportProgress(150)
+	p.ReportMessage("halfway")
+	p.ReportProgress(50)
+
+	wanted := []Progress{
+		{TaskIndex: 1, TaskName: "definitionTestTask"},
+		{TaskIndex: 1, TaskName: "definitionTestTask", Percent: 100},
+		{TaskIndex: 1, TaskName: "definitionTestTask", Percent: 100, Message: "halfway"},
+		{TaskIndex: 1, TaskName: "definitionTestTask", Percent: 50, Message: "halfway"},
+	}
+	if !slices.Equal(reported, wanted) {
+		t.Errorf("invalid progress: got %+v expected %+v", reported, wanted)
+	}
+	if p.Progress() != wanted[len(wanted)-1] {
+		t.Errorf("invalid progress: got %+v expected %+v", p.Progress(), wanted[len(wanted)-1])
+	}
+
+	// the progress is reset when the next task starts
+	p.startTask(2, definitionTestTask{})
+	if p.Progress() != (Progress{TaskIndex: 2, TaskName: "definitionTestTask"}) {
+		t.Errorf("progress should be reset for the next task, received %+v", p.Progress())
+	}
+}
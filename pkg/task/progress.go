@@ -0,0 +1,9 @@
+package task
+
+// Progress is the progress of the tasks executed with a pipeline.
+type Progress struct {
+	TaskIndex int     // index of the current task in the sequence
+	TaskName  string  // name of the current task
+	Percent   float64 // progress of the current task from 0 to 100, as reported by its handler
+	Message   string  // last message reported by the handler of the current task
+}
@@ -0,0 +1,186 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownKind is returned, wrapped in a ValidationError, when a task kind is not registered.
+var ErrUnknownKind = errors.New("unknown task kind")
+
+// DefaultRegistry is the Registry used by Definition, Register, RegisterType and KindOf.
+var DefaultRegistry = NewRegistry()
+
+// Register registers kind in the DefaultRegistry, see Registry.Register.
+func Register(kind string, t Task, d Decoder) error {
+	return DefaultRegistry.Register(kind, t, d)
+}
+
+// RegisterType registers the type of t under kind in the DefaultRegistry, see Registry.RegisterType.
+func RegisterType(kind string, t Task) error {
+	return DefaultRegistry.RegisterType(kind, t)
+}
+
+// KindOf returns the kind the type of t is registered under in the DefaultRegistry.
+func KindOf(t Task) (string, error) {
+	return DefaultRegistry.Kind(t)
+}
+
+// Decoder builds a task from its spec.
+type Decoder func(spec Spec) (Task, error)
+
+// Validator is implemented by tasks which check their fields after they are decoded.
+type Validator interface {
+	Validate() error
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		kinds: make(map[string]registryEntry),
+		types: make(map[reflect.Type]string),
+	}
+}
+
+// Registry holds the task kinds which can be built from data, such as a Definition in a configuration file.
+// Each kind has a decoder to build the task from its spec, and the type of the task it builds to encode tasks again.
+type Registry struct {
+	kinds map[string]registryEntry
+	types map[reflect.Type]string
+	mux   sync.RWMutex
+}
+
+type registryEntry struct {
+	typ     reflect.Type
+	decoder Decoder
+}
+
+// Register registers kind with decoder d, which builds tasks of the same type as t.
+// If d is nil, the spec is decoded into a new value of the type of t.
+// Each kind and each type can only be registered once.
+func (r *Registry) Register(kind string, t Task, d Decoder) error {
+	if kind == "" {
+		return fmt.Errorf("task kind required")
+	}
+	if t == nil {
+		return fmt.Errorf("task required for kind %s", kind)
+	}
+
+	typ := reflect.TypeOf(t)
+	if d == nil {
+		d = typeDecoder(typ)
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if _, ok := r.kinds[kind]; ok {
+		return fmt.Errorf("task kind %s already registered", kind)
+	}
+	if k, ok := r.types[typ]; ok {
+		return fmt.Errorf("task type %s already registered as kind %s", typ, k)
+	}
+
+	r.kinds[kind] = registryEntry{typ: typ, decoder: d}
+	r.types[typ] = kind
+	return nil
+}
+
+// RegisterType registers the type of t under kind, decoding specs into a new value of that type.
+func (r *Registry) RegisterType(kind string, t Task) error {
+	return r.Register(kind, t, nil)
+}
+
+// Decode builds a task of the input kind from spec.
+// Returns a ValidationError if the kind is not registered, the spec cannot be decoded, or the task is not valid.
+func (r *Registry) Decode(kind string, spec Spec) (Task, error) {
+	if kind == "" {
+		return nil, &ValidationError{Err: errors.New("task kind required")}
+	}
+
+	r.mux.RLock()
+	e, ok := r.kinds[kind]
+	r.mux.RUnlock()
+
+	if !ok {
+		return nil, &ValidationError{Kind: kind, Err: fmt.Errorf("%w, expected one of %s", ErrUnknownKind, strings.Join(r.Kinds(), ", "))}
+	}
+
+	t, err := e.decoder(spec)
+	if err != nil {
+		return nil, &ValidationError{Kind: kind, Err: fmt.Errorf("invalid spec: %w", err)}
+	}
+
+	if v, ok := t.(Validator); ok {
+		if err = v.Validate(); err != nil {
+			return nil, &ValidationError{Kind: kind, Err: err}
+		}
+	}
+	return t, nil
+}
+
+// Kind returns the kind the type of t is registered under.
+func (r *Registry) Kind(t Task) (string, error) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	kind, ok := r.types[reflect.TypeOf(t)]
+	if !ok {
+		return "", fmt.Errorf("task type %T not registered", t)
+	}
+	return kind, nil
+}
+
+// Kinds returns the registered kinds in alphabetical order.
+func (r *Registry) Kinds() []string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	kinds := make([]string, 0, len(r.kinds))
+	for kind := range r.kinds {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+	return kinds
+}
+
+// typeDecoder returns a Decoder which decodes the spec into a new value of typ.
+// Pointer types are decoded into a new value of the type they point to.
+func typeDecoder(typ reflect.Type) Decoder {
+	return func(spec Spec) (Task, error) {
+		if typ.Kind() == reflect.Pointer {
+			v := reflect.New(typ.Elem())
+			if err := spec.Decode(v.Interface()); err != nil {
+				return nil, err
+			}
+			return v.Interface().(Task), nil
+		}
+
+		v := reflect.New(typ)
+		if err := spec.Decode(v.Interface()); err != nil {
+			return nil, err
+		}
+		return v.Elem().Interface().(Task), nil
+	}
+}
+
+// ValidationError is returned when a task cannot be built from its kind and spec.
+// Use errors.As to retrieve it from the error.
+type ValidationError struct {
+	Kind string // kind of the task, or empty if the kind is missing
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	if e.Kind == "" {
+		return "invalid task: " + e.Err.Error()
+	}
+	return "invalid task of kind " + e.Kind + ": " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
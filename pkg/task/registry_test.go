@@ -0,0 +1,141 @@
+package task
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type registryTestTask struct {
+	definitionTestTask `yaml:",inline"`
+	Target             string
+}
+
+func (t registryTestTask) Validate() error {
+	if t.Target == "" {
+		return errors.New("target required")
+	}
+	return nil
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := NewRegistry()
+	if err := r.RegisterType("test", definitionTestTask{}); err != nil {
+		t.Fatalf("cannot register type: %v", err)
+	}
+
+	var tests = []struct {
+		name string
+		kind string
+		task Task
+	}{
+		{name: "empty kind", kind: "", task: registryTestTask{}},
+		{name: "nil task", kind: "nil", task: nil},
+		{name: "duplicate kind", kind: "test", task: registryTestTask{}},
+		{name: "duplicate type", kind: "other", task: definitionTestTask{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.Register(tt.kind, tt.task, nil); err == nil {
+				t.Errorf("registering %s should return an error", tt.kind)
+			}
+		})
+	}
+}
+
+func TestRegistry_Kind(t *testing.T) {
+	r := NewRegistry()
+	if err := r.RegisterType("test", definitionTestTask{}); err != nil {
+		t.Fatalf("cannot register type: %v", err)
+	}
+
+	if kind, err := r.Kind(definitionTestTask{}); err != nil || kind != "test" {
+		t.Errorf("kind should be test, received %s (%v)", kind, err)
+	}
+	if _, err := r.Kind(&definitionTestTask{}); err == nil {
+		t.Errorf("unregistered type should return an error")
+	}
+}
+
+func TestRegistry_Decode(t *testing.T) {
+	r := NewRegistry()
+	if err := r.RegisterType("test", registryTestTask{}); err != nil {
+		t.Fatalf("cannot register type: %v", err)
+	}
+	if err := r.Register("upper", &definitionTestTask{}, func(spec Spec) (Task, error) {
+		var s struct {
+			Text string `json:"text" yaml:"text"`
+		}
+		if err := spec.Decode(&s); err != nil {
+			return nil, err
+		}
+		return &definitionTestTask{Message: strings.ToUpper(s.Text)}, nil
+	}); err != nil {
+		t.Fatalf("cannot register decoder: %v", err)
+	}
+
+	var tests = []struct {
+		name    string
+		kind    string
+		json    string
+		yaml    string
+		wanted  Task
+		wantErr string
+	}{
+		{name: "type", kind: "test", json: `{"Target":"x","Repeat":1}`, yaml: "target: x\nrepeat: 1", wanted: registryTestTask{definitionTestTask: definitionTestTask{Repeat: 1}, Target: "x"}},
+		{name: "decoder", kind: "upper", json: `{"text":"hi"}`, yaml: "text: hi", wanted: &definitionTestTask{Message: "HI"}},
+		{name: "missing kind", kind: "", json: `{}`, yaml: "{}", wantErr: "invalid task: task kind required"},
+		{name: "unknown kind", kind: "ping", json: `{}`, yaml: "{}", wantErr: "invalid task of kind ping: unknown task kind, expected one of test, upper"},
+		{name: "unknown field", kind: "test", json: `{"Target":"x","Port":80}`, yaml: "target: x\nport: 80", wantErr: "invalid task of kind test: invalid spec"},
+		{name: "invalid field", kind: "upper", json: `{"text":1}`, yaml: "text: [1]", wantErr: "invalid task of kind upper: invalid spec"},
+		{name: "validate", kind: "test", json: `{"Repeat":1}`, yaml: "repeat: 1", wantErr: "invalid task of kind test: target required"},
+	}
+
+	for _, tt := range tests {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(tt.yaml), &node); err != nil {
+			t.Fatalf("invalid yaml %s: %v", tt.yaml, err)
+		}
+
+		specs := map[string]Spec{"json": JSONSpec([]byte(tt.json)), "yaml": YAMLSpec(node.Content[0])}
+		for format, spec := range specs {
+			t.Run(tt.name+"/"+format, func(t *testing.T) {
+				task, err := r.Decode(tt.kind, spec)
+				if tt.wantErr != "" {
+					var verr *ValidationError
+					if !errors.As(err, &verr) || verr.Kind != tt.kind {
+						t.Fatalf("decode should return a ValidationError for kind %s, received %v", tt.kind, err)
+					}
+					if !strings.HasPrefix(err.Error(), tt.wantErr) {
+						t.Errorf("error should start with %q, received %q", tt.wantErr, err)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("decode should not return an error, received %v", err)
+				}
+				if !reflect.DeepEqual(task, tt.wanted) {
+					t.Errorf("task should be %+v, received %+v", tt.wanted, task)
+				}
+			})
+		}
+	}
+}
+
+func TestRegistry_DecodeUnknownKind(t *testing.T) {
+	_, err := NewRegistry().Decode("ping", JSONSpec(nil))
+	if !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("error should wrap ErrUnknownKind, received %v", err)
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	if kind, err := KindOf(definitionTestTask{}); err != nil || kind != "definition-test" {
+		t.Errorf("kind should be definition-test, received %s (%v)", kind, err)
+	}
+}
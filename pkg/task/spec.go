@@ -0,0 +1,55 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the encoded specification of a task, which a Decoder decodes into a value.
+// Fields in the spec which do not exist in the value are rejected.
+type Spec interface {
+	Decode(v any) error
+}
+
+// JSONSpec returns the Spec for JSON encoded data.
+func JSONSpec(data []byte) Spec {
+	return jsonSpec(data)
+}
+
+// YAMLSpec returns the Spec for a YAML node.
+func YAMLSpec(node *yaml.Node) Spec {
+	return yamlSpec{node: node}
+}
+
+type jsonSpec []byte
+
+func (s jsonSpec) Decode(v any) error {
+	if len(bytes.TrimSpace(s)) == 0 || bytes.Equal(s, []byte("null")) {
+		return nil
+	}
+
+	d := json.NewDecoder(bytes.NewReader(s))
+	d.DisallowUnknownFields()
+	return d.Decode(v)
+}
+
+type yamlSpec struct {
+	node *yaml.Node
+}
+
+func (s yamlSpec) Decode(v any) error {
+	if s.node == nil || s.node.IsZero() {
+		return nil
+	}
+
+	// yaml.Node.Decode does not reject unknown fields, so the node is decoded again with a strict decoder
+	data, err := yaml.Marshal(s.node)
+	if err != nil {
+		return err
+	}
+	d := yaml.NewDecoder(bytes.NewReader(data))
+	d.KnownFields(true)
+	return d.Decode(v)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/exec"
 	"strings"
@@ -44,6 +45,17 @@ func (t ExecTask) HandlerPool(ctx context.Context, timeout time.Duration) *task.
 	return ExecTaskHandlerPool(ctx, t.name(), timeout)
 }
 
+// Validate checks that the program and the path to run it from are set.
+func (t ExecTask) Validate() error {
+	if t.Program == "" {
+		return fmt.Errorf("program required")
+	}
+	if t.Path == "" {
+		return fmt.Errorf("path required")
+	}
+	return nil
+}
+
 func (t ExecTask) name() string {
 	return strings.Join([]string{
 		execTaskName,
@@ -67,13 +79,13 @@ func handleExecTask(ctx context.Context, t task.Task, p *task.Pipeline) error {
 
 	var err error
 	if err = cmd.Start(); err != nil {
-		fmt.Println(err)
+		p.Logger(t).LogAttrs(ctx, slog.LevelError, "cannot start program", slog.String("error", err.Error()))
 		return err
 	}
 	if err = cmd.Wait(); err != nil {
-		fmt.Println(err)
+		p.Logger(t).LogAttrs(ctx, slog.LevelError, "program failed", slog.String("output", out.String()), slog.String("error", err.Error()))
 		return err
 	}
-	fmt.Println(string(out.Bytes()))
+	p.Logger(t).LogAttrs(ctx, slog.LevelInfo, "program finished", slog.String("output", out.String()))
 	return nil
 }
@@ -2,6 +2,7 @@ package taskLibrary
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -39,6 +40,14 @@ func (t LogTask) HandlerPool(ctx context.Context, timeout time.Duration) *task.H
 	return LogTaskHandlerPool(ctx, timeout)
 }
 
+// Validate checks that the message is set.
+func (t LogTask) Validate() error {
+	if t.Message == "" {
+		return fmt.Errorf("message required")
+	}
+	return nil
+}
+
 func LogTaskHandler(timeout time.Duration) task.Handler {
 	return task.NewHandler(logTaskName, timeout, handleLogTask)
 }
@@ -38,6 +38,14 @@ func (t PrintTask) HandlerPool(ctx context.Context, timeout time.Duration) *task
 	return PrintTaskHandlerPool(ctx, timeout)
 }
 
+// Validate checks that the message is set.
+func (t PrintTask) Validate() error {
+	if t.Message == "" {
+		return fmt.Errorf("message required")
+	}
+	return nil
+}
+
 func PrintTaskHandler(timeout time.Duration) task.Handler {
 	return task.NewHandler(printTaskName, timeout, handlePrintTask)
 }
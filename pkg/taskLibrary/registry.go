@@ -0,0 +1,42 @@
+package taskLibrary
+
+import (
+	"github.com/jantytgat/go-jobs/pkg/task"
+)
+
+// Kinds of the tasks in the library, as used in task definitions such as {"kind": "exec", "spec": {...}}.
+const (
+	EmptyTaskKind      = "empty"
+	EmptyErrorTaskKind = "empty-error"
+	ExecTaskKind       = "exec"
+	LogTaskKind        = "log"
+	PrintTaskKind      = "print"
+)
+
+// The tasks in the library are registered in the default task registry, so importing the package is enough to decode them.
+func init() {
+	if err := Register(task.DefaultRegistry); err != nil {
+		panic(err)
+	}
+}
+
+// Register registers the kinds of the tasks in the library in registry r.
+func Register(r *task.Registry) error {
+	kinds := []struct {
+		kind string
+		task task.Task
+	}{
+		{EmptyTaskKind, EmptyTask{}},
+		{EmptyErrorTaskKind, EmptyErrorTask{}},
+		{ExecTaskKind, ExecTask{}},
+		{LogTaskKind, LogTask{}},
+		{PrintTaskKind, PrintTask{}},
+	}
+
+	for _, k := range kinds {
+		if err := r.RegisterType(k.kind, k.task); err != nil {
+			return err
+		}
+	}
+	return nil
+}